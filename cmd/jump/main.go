@@ -2,11 +2,19 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/MrSnakeDoc/jump/internal/app"
 )
 
 func main() {
+	if handled, err := app.RunCommand(os.Args[1:]); handled {
+		if err != nil {
+			log.Fatalf("❌ jump %s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
 	if err := app.New().Run(); err != nil {
 		log.Fatalf("❌ jump failed to start: %v", err)
 	}
@@ -17,6 +17,8 @@ import (
 	"github.com/MrSnakeDoc/jump/internal/logger"
 	"github.com/MrSnakeDoc/jump/internal/redis"
 	"github.com/MrSnakeDoc/jump/internal/scheduler"
+	"github.com/MrSnakeDoc/jump/internal/sources"
+	_ "github.com/MrSnakeDoc/jump/internal/sources/homepage" // registers the homepage source
 	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
 	"github.com/MrSnakeDoc/jump/internal/version"
 )
@@ -27,9 +29,11 @@ type App struct {
 	server           *httpserver.Server
 	redisClient      *goredis.Client
 	memIndex         *index.MemoryIndex
-	reloader         *scheduler.HomepageReloader
+	reloader         *scheduler.ServiceReloader
 	bookmarkReloader *scheduler.BookmarkReloader
 	gc               *scheduler.GarbageCollector
+	prober           *scheduler.HealthProber
+	decayer          *scheduler.FrecencyDecayer
 }
 
 func New() *App {
@@ -39,21 +43,7 @@ func New() *App {
 
 	// Initialize Redis early - fail fast if unavailable
 	loggerClient.Infof("Connecting to Redis at %s", cfg.RedisAddr)
-	redisClient, err := redis.New(redis.ConnectOptions{
-		Addr:           cfg.RedisAddr,
-		User:           cfg.RedisUser,
-		Password:       cfg.RedisPassword,
-		RedisDB:        cfg.RedisDB,
-		DialTimeout:    cfg.RedisDT,
-		ReadTimeout:    cfg.RedisRT,
-		WriteTimeout:   cfg.RedisWT,
-		PoolSize:       cfg.RedisPoolSize,
-		ConnectTimeout: cfg.RedisConnectTimeout,
-		RetryInterval:  cfg.RedisRetryInterval,
-		MaxWait:        cfg.RedisMaxWait,
-		PingTimeout:    cfg.RedisPingTimeout,
-		WarnThreshold:  cfg.RedisWarnThreshold,
-	}, loggerClient)
+	redisClient, err := connectRedis(cfg, loggerClient)
 	if err != nil {
 		loggerClient.Errorf("Failed to connect to Redis: %v", err)
 		os.Exit(1)
@@ -69,16 +59,24 @@ func New() *App {
 	// Try to sync services from Redis to memory on startup
 	syncer := scheduler.NewRedisSyncer(store, memIndex, loggerClient)
 	if err := syncer.Sync(context.Background()); err != nil {
-		loggerClient.Warn("failed to sync from redis on startup, will load from homepage",
+		loggerClient.Warn("failed to sync from redis on startup, will load from sources",
 			logger.Error(err))
 	}
 
 	// Create manual reload trigger channel
 	reloadTrigger := make(chan struct{}, 1)
 
-	// Initialize homepage reloader
-	reloader := scheduler.NewHomepageReloader(
-		cfg.ServiceFile,
+	// Initialize the sources enabled in JUMP_SOURCES - fail fast on unknown names
+	enabledSources, err := sources.Enabled(cfg, loggerClient)
+	if err != nil {
+		loggerClient.Errorf("Invalid JUMP_SOURCES: %v", err)
+		os.Exit(1)
+	}
+	reconciler := sources.NewReconciler(enabledSources, cfg.OverlayFile, loggerClient)
+
+	// Initialize service reloader
+	reloader := scheduler.NewServiceReloader(
+		reconciler,
 		store,
 		memIndex,
 		loggerClient,
@@ -95,15 +93,43 @@ func New() *App {
 		scheduler.DefaultGCThreshold,
 	)
 
-	// Initialize bookmark reloader (if bookmark file is configured)
+	// Initialize health prober (if enabled)
+	var prober *scheduler.HealthProber
+	if cfg.HealthInterval > 0 {
+		prober = scheduler.NewHealthProber(
+			store,
+			memIndex,
+			loggerClient,
+			cfg.HealthInterval,
+			cfg.HealthJitter,
+			cfg.TLSTimeout,
+		)
+	} else {
+		loggerClient.Info("health prober disabled, services are validated on each request")
+	}
+
+	// Initialize frecency decayer (if enabled)
+	var decayer *scheduler.FrecencyDecayer
+	if cfg.FrecencyHalfLife > 0 {
+		decayer = scheduler.NewFrecencyDecayer(
+			store,
+			memIndex,
+			loggerClient,
+			cfg.FrecencyDecayInterval,
+			cfg.FrecencyHalfLife,
+		)
+	} else {
+		loggerClient.Info("frecency decay disabled, usage never expires")
+	}
+
+	// Initialize bookmark reloader (if a source lists bookmarks)
 	var bookmarkReloader *scheduler.BookmarkReloader
 	var bookmarkReloadTrigger chan struct{}
-	if cfg.BookmarkFile != "" {
-		loggerClient.Info("bookmark file configured, initializing bookmark reloader",
-			logger.String("file", cfg.BookmarkFile))
+	if reconciler.HasBookmarks() {
+		loggerClient.Info("bookmarks configured, initializing bookmark reloader")
 		bookmarkReloadTrigger = make(chan struct{}, 1)
 		bookmarkReloader = scheduler.NewBookmarkReloader(
-			cfg.BookmarkFile,
+			reconciler,
 			store,
 			memIndex,
 			loggerClient,
@@ -111,7 +137,7 @@ func New() *App {
 			bookmarkReloadTrigger,
 		)
 	} else {
-		loggerClient.Info("bookmark file not configured, bookmark search disabled")
+		loggerClient.Info("no source lists bookmarks, bookmark search disabled")
 	}
 
 	// Dependencies passed to routes (extend as needed).
@@ -134,6 +160,19 @@ func New() *App {
 		SkipTLSValidation:     cfg.SkipTLSValidation,
 		MaxCandidates:         cfg.MaxCandidates,
 		AllowedDomains:        cfg.AllowedDomains,
+		HealthInterval:        cfg.HealthInterval,
+		HealthStaleAfter:      cfg.HealthStaleAfter,
+		ChooserMargin:         cfg.ChooserMargin,
+		ClientIdentity:        cfg.ClientIdentity,
+		ClientHeader:          cfg.ClientHeader,
+		ClientCookie:          cfg.ClientCookie,
+		PersonalUsageWeight:   cfg.PersonalUsageWeight,
+		GlobalUsageWeight:     cfg.GlobalUsageWeight,
+		FrecencyHalfLife:      cfg.FrecencyHalfLife,
+		FrecencyDecayInterval: cfg.FrecencyDecayInterval,
+		SearchEngines:         cfg.SearchEngines,
+		FallbackEngine:        cfg.FallbackEngine,
+		UnifiedSearch:         cfg.UnifiedSearch,
 		ReloadTrigger:         reloadTrigger,
 		BookmarkReloadTrigger: bookmarkReloadTrigger,
 	}
@@ -149,9 +188,30 @@ func New() *App {
 		reloader:         reloader,
 		bookmarkReloader: bookmarkReloader,
 		gc:               gc,
+		prober:           prober,
+		decayer:          decayer,
 	}
 }
 
+// connectRedis connects to Redis with the configured retry policy
+func connectRedis(cfg *config.Config, log logger.Logger) (*goredis.Client, error) {
+	return redis.New(redis.ConnectOptions{
+		Addr:           cfg.RedisAddr,
+		User:           cfg.RedisUser,
+		Password:       cfg.RedisPassword,
+		RedisDB:        cfg.RedisDB,
+		DialTimeout:    cfg.RedisDT,
+		ReadTimeout:    cfg.RedisRT,
+		WriteTimeout:   cfg.RedisWT,
+		PoolSize:       cfg.RedisPoolSize,
+		ConnectTimeout: cfg.RedisConnectTimeout,
+		RetryInterval:  cfg.RedisRetryInterval,
+		MaxWait:        cfg.RedisMaxWait,
+		PingTimeout:    cfg.RedisPingTimeout,
+		WarnThreshold:  cfg.RedisWarnThreshold,
+	}, log)
+}
+
 func (a *App) Run() error {
 	a.logger.Infof("🚀 Starting Jump v%s on %s", version.Version, a.cfg.ListenPort)
 	a.logger.Infof("Jump %s (commit=%s, built=%s, go=%s)",
@@ -160,11 +220,11 @@ func (a *App) Run() error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	// Start homepage reloader (loads services and starts periodic refresh)
+	// Start service reloader (loads services and starts periodic refresh)
 	if err := a.reloader.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start homepage reloader: %w", err)
+		return fmt.Errorf("failed to start service reloader: %w", err)
 	}
-	a.logger.Info("homepage reloader started",
+	a.logger.Info("service reloader started",
 		logger.Duration("interval", a.cfg.ReloadInterval))
 
 	// Start bookmark reloader (if enabled)
@@ -183,6 +243,25 @@ func (a *App) Run() error {
 	a.logger.Info("garbage collector started",
 		logger.Duration("interval", a.cfg.GCInterval))
 
+	// Start health prober (if enabled)
+	if a.prober != nil {
+		if err := a.prober.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start health prober: %w", err)
+		}
+		a.logger.Info("health prober started",
+			logger.Duration("interval", a.cfg.HealthInterval))
+	}
+
+	// Start frecency decayer (if enabled)
+	if a.decayer != nil {
+		if err := a.decayer.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start frecency decayer: %w", err)
+		}
+		a.logger.Info("frecency decayer started",
+			logger.Duration("interval", a.cfg.FrecencyDecayInterval),
+			logger.Duration("half_life", a.cfg.FrecencyHalfLife))
+	}
+
 	errCh := make(chan error, 1)
 	go func() {
 		if err := a.server.Start(); err != nil {
@@ -208,6 +287,16 @@ func (a *App) Run() error {
 	// Stop garbage collector
 	a.gc.Stop()
 
+	// Stop health prober
+	if a.prober != nil {
+		a.prober.Stop()
+	}
+
+	// Stop frecency decayer
+	if a.decayer != nil {
+		a.decayer.Stop()
+	}
+
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.cfg.ShutdownTimeout)
 	defer cancel()
 	if err := a.server.Stop(shutdownCtx); err != nil {
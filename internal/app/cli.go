@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/MrSnakeDoc/jump/internal/config"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
+	"github.com/MrSnakeDoc/jump/internal/utils"
+)
+
+// RunCommand runs a maintenance command instead of the server:
+//
+//	jump export [-o file]
+//	jump import [-mode merge|replace] file
+//
+// It reports false when args name no command. The commands work on Redis directly:
+// on a running instance, prefer /api/v1/export and /api/v1/import, which also
+// refresh the in-memory index.
+func RunCommand(args []string) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+
+	switch args[0] {
+	case "export":
+		return true, runExport(args[1:])
+	case "import":
+		return true, runImport(args[1:])
+	default:
+		return false, nil
+	}
+}
+
+// runExport writes the learning state snapshot to a file or stdout
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := flags.String("o", "-", "snapshot file, - for stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := store.Export(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if *output == "-" {
+		err = writeSnapshot(os.Stdout, snapshot)
+	} else {
+		err = writeSnapshotFile(*output, snapshot)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "exported %d services and %d bookmarks\n", len(snapshot.Services), len(snapshot.Bookmarks))
+	return nil
+}
+
+// writeSnapshotFile writes a snapshot to a file. The file is removed when writing
+// or closing it fails, so a truncated export is never left behind.
+func writeSnapshotFile(path string, snapshot *redisstore.Snapshot) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+
+	err = writeSnapshot(file, snapshot)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close snapshot file: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	return nil
+}
+
+// writeSnapshot encodes a snapshot as indented JSON
+func writeSnapshot(out io.Writer, snapshot *redisstore.Snapshot) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// runImport restores a snapshot from a file or stdin
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	mode := flags.String("mode", redisstore.ImportModeMerge, "merge (sum counters) or replace (wipe first)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: jump import [-mode merge|replace] <file|->")
+	}
+
+	in := io.Reader(os.Stdin)
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open snapshot file: %w", err)
+		}
+		defer utils.Close(file)
+		in = file
+	}
+
+	var snapshot redisstore.Snapshot
+	decoder := json.NewDecoder(in)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&snapshot); err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	summary, err := store.Import(context.Background(), &snapshot, *mode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "imported %d services, %d bookmarks and %d entries (%s)\n",
+		summary.Services, summary.Bookmarks, summary.Entries, summary.Mode)
+	return nil
+}
+
+// openStore connects to the configured Redis. Logs go to stderr, stdout is kept for data.
+func openStore() (*redisstore.Store, error) {
+	cfg := config.LoadRedis()
+
+	redisClient, err := connectRedis(cfg, logger.New(cfg.LogLevel, cfg.PrettyLog))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return redisstore.NewStore(redisClient), nil
+}
@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
 )
 
 type Config struct {
@@ -18,6 +20,7 @@ type Config struct {
 
 	ServiceFile       string        // path to the service.yaml file in homepage directory
 	BookmarkFile      string        // path to the bookmarks.yaml file (optional, empty = bookmarks disabled)
+	OverlayFile       string        // path to the Jump overlay file (optional, empty = no overlay)
 	HomepageURL       string        // fallback URL when no service matches (ex: https://homepage.domain.ext)
 	ReloadInterval    time.Duration // interval to reload services.yaml (default: 24h)
 	GCInterval        time.Duration // interval to run garbage collection (default: 24h)
@@ -25,6 +28,36 @@ type Config struct {
 	SkipTLSValidation bool          // skip TLS validation (useful for dev/local)
 	MaxCandidates     int           // max number of candidates to validate (default: 3, 0 = no limit)
 	AllowedDomains    []string      // allowed domain suffixes for redirects (derived from AllowedHosts)
+	HealthInterval    time.Duration // interval between background health probes (default: 1m, 0 = disabled)
+	HealthJitter      time.Duration // random delay added to each probe interval (default: 10s)
+	HealthStaleAfter  time.Duration // age after which probe results are ignored (default: 3m)
+	ChooserMargin     float64       // score margin under which top candidates are offered in a chooser (default: 0 = disabled)
+
+	// Sources
+	Sources []string // sources services and bookmarks are loaded from, the first one wins on conflicts (default: homepage)
+
+	// Personalized usage
+	ClientIdentity      string  // how clients are told apart: "none", "ip", "header" or "cookie" (default: none)
+	ClientHeader        string  // trusted auth header holding the user name (default: Remote-User)
+	ClientCookie        string  // cookie holding the client ID (default: jump_client)
+	PersonalUsageWeight float64 // weight of the client's own usage in ranking (default: 0.7)
+	GlobalUsageWeight   float64 // weight of the shared usage in ranking (default: 0.3)
+
+	// Frecency
+	FrecencyHalfLife      time.Duration // time after which usage counts for half (default: 336h = 14 days, 0 = no decay)
+	FrecencyDecayInterval time.Duration // interval between decay passes (default: 1h)
+
+	// Bookmark URL policy
+	BookmarkSchemes        []string // URL schemes bookmarks may use (default: http, https)
+	BookmarkAllowedDomains []string // domains bookmarks may point to, subdomains included (empty = any)
+	BookmarkDeniedDomains  []string // domains bookmarks may never point to, subdomains included
+
+	// Unified search
+	UnifiedSearch bool // plain queries rank services and bookmarks together (default: false, bookmarks need @)
+
+	// Web search
+	SearchEngines  map[string]string // named web search engines: name -> URL template with {q} (used by !bangs)
+	FallbackEngine string            // search engine used when nothing matches (empty = redirect to HomepageURL)
 
 	// Redis
 	RedisAddr             string        // ex: "localhost:6379"
@@ -60,6 +93,7 @@ func Load() *Config {
 		// Service file
 		ServiceFile:       getenv("JUMP_SERVICE_FILE", "/app/services.yaml"),
 		BookmarkFile:      getenv("JUMP_BOOKMARK_FILE", ""), // Optional, empty = bookmarks disabled
+		OverlayFile:       getenv("JUMP_OVERLAY_FILE", ""),  // Optional, empty = no overlay
 		HomepageURL:       requireEnv("JUMP_HOMEPAGE_URL"),
 		ReloadInterval:    mustDuration("JUMP_RELOAD_SOURCE_INTERVAL", 24*time.Hour),
 		GCInterval:        mustDuration("JUMP_GC_INTERVAL", 24*time.Hour),
@@ -67,22 +101,36 @@ func Load() *Config {
 		SkipTLSValidation: mustBool("JUMP_SKIP_TLS_VALIDATION", false),
 		MaxCandidates:     getenvInt("JUMP_MAX_CANDIDATES", 3),
 		AllowedDomains:    extractDomains(requireEnvSlice("JUMP_ALLOWED_HOSTS")),
+		HealthInterval:    mustDuration("JUMP_HEALTH_INTERVAL", time.Minute),
+		HealthJitter:      mustDuration("JUMP_HEALTH_JITTER", 10*time.Second),
+		HealthStaleAfter:  mustDuration("JUMP_HEALTH_STALE_AFTER", 3*time.Minute),
+		ChooserMargin:     getenvFloat("JUMP_CHOOSER_MARGIN", 0),
 
-		// Redis settings
-		RedisAddr:             requireEnv("JUMP_REDIS_ADDR"),
-		RedisUser:             getenv("JUMP_REDIS_USERNAME", "default"),
-		RedisPasswordRequired: mustBool("JUMP_REDIS_PASSWORD_REQUIRED", true),
-		RedisPassword:         getenv("JUMP_REDIS_PASSWORD", ""),
-		RedisDB:               requireEnvInt("JUMP_REDIS_DB"),
-		RedisDT:               mustDuration("REDIS_DIAL_TIMEOUT", 5*time.Second),
-		RedisRT:               mustDuration("REDIS_READ_TIMEOUT", 3*time.Second),
-		RedisWT:               mustDuration("REDIS_WRITE_TIMEOUT", 3*time.Second),
-		RedisMaxWait:          mustDuration("REDIS_MAX_WAIT", 10*time.Second),
-		RedisPingTimeout:      mustDuration("REDIS_PING_TIMEOUT", 5*time.Second),
-		RedisPoolSize:         getenvInt("REDIS_POOL_SIZE", 10),
-		RedisConnectTimeout:   mustDuration("REDIS_CONNECT_TIMEOUT", 30*time.Second),
-		RedisRetryInterval:    mustDuration("REDIS_RETRY_INTERVAL", 2*time.Second),
-		RedisWarnThreshold:    getenvInt("REDIS_WARN_THRESHOLD", 3),
+		// Sources
+		Sources: lowerAll(splitAndTrim(getenv("JUMP_SOURCES", "homepage"))),
+
+		// Personalized usage
+		ClientIdentity:      strings.ToLower(getenv("JUMP_CLIENT_IDENTITY", "none")),
+		ClientHeader:        getenv("JUMP_CLIENT_HEADER", "Remote-User"),
+		ClientCookie:        getenv("JUMP_CLIENT_COOKIE", "jump_client"),
+		PersonalUsageWeight: getenvFloat("JUMP_PERSONAL_USAGE_WEIGHT", 0.7),
+		GlobalUsageWeight:   getenvFloat("JUMP_GLOBAL_USAGE_WEIGHT", 0.3),
+
+		// Frecency
+		FrecencyHalfLife:      mustDuration("JUMP_FRECENCY_HALF_LIFE", 14*24*time.Hour),
+		FrecencyDecayInterval: mustDuration("JUMP_FRECENCY_DECAY_INTERVAL", time.Hour),
+
+		// Bookmark URL policy
+		BookmarkSchemes:        lowerAll(splitAndTrim(getenv("JUMP_BOOKMARK_SCHEMES", "http,https"))),
+		BookmarkAllowedDomains: lowerAll(splitAndTrim(getenv("JUMP_BOOKMARK_ALLOWED_DOMAINS", ""))),
+		BookmarkDeniedDomains:  lowerAll(splitAndTrim(getenv("JUMP_BOOKMARK_DENIED_DOMAINS", ""))),
+
+		// Unified search
+		UnifiedSearch: mustBool("JUMP_UNIFIED_SEARCH", false),
+
+		// Web search
+		SearchEngines:  parseSearchEngines(getenv("JUMP_SEARCH_ENGINES", "")),
+		FallbackEngine: strings.ToLower(getenv("JUMP_FALLBACK_ENGINE", "")),
 
 		// Access restrictions
 		AllowedHosts: requireEnvSlice("JUMP_ALLOWED_HOSTS"),
@@ -90,9 +138,31 @@ func Load() *Config {
 		TrustProxy:   mustBool("JUMP_TRUST_PROXY", true),
 	}
 
-	// Validate Redis password configuration
-	if cfg.RedisPasswordRequired && cfg.RedisPassword == "" {
-		panic("❌ FATAL: JUMP_REDIS_PASSWORD is required when JUMP_REDIS_PASSWORD_REQUIRED=true")
+	// Redis settings
+	loadRedis(cfg)
+
+	// Validate sources (names are checked against the source registry on startup)
+	if len(cfg.Sources) == 0 {
+		panic("❌ FATAL: JUMP_SOURCES must name at least one source")
+	}
+
+	// Validate client identity mode
+	switch cfg.ClientIdentity {
+	case "none", "ip", "header", "cookie":
+	default:
+		panic(fmt.Sprintf("❌ FATAL: Invalid JUMP_CLIENT_IDENTITY %q (expected none, ip, header or cookie)", cfg.ClientIdentity))
+	}
+
+	// Validate frecency decay configuration
+	if cfg.FrecencyHalfLife > 0 && cfg.FrecencyDecayInterval <= 0 {
+		panic("❌ FATAL: JUMP_FRECENCY_DECAY_INTERVAL must be positive when JUMP_FRECENCY_HALF_LIFE is set")
+	}
+
+	// Validate fallback engine configuration
+	if cfg.FallbackEngine != "" {
+		if _, ok := cfg.SearchEngines[cfg.FallbackEngine]; !ok {
+			panic(fmt.Sprintf("❌ FATAL: JUMP_FALLBACK_ENGINE %q is not defined in JUMP_SEARCH_ENGINES", cfg.FallbackEngine))
+		}
 	}
 
 	// Log config only in debug mode with redacted sensitive fields
@@ -108,6 +178,40 @@ func Load() *Config {
 	return cfg
 }
 
+// LoadRedis loads only the logging and Redis settings, for the commands
+// working on Redis without serving (jump export, jump import)
+func LoadRedis() *Config {
+	cfg := &Config{
+		LogLevel:  getenv("JUMP_LOG_LEVEL", "info"),
+		PrettyLog: mustBool("JUMP_PRETTY_LOG", true),
+	}
+	loadRedis(cfg)
+	return cfg
+}
+
+// loadRedis reads and validates the Redis settings
+func loadRedis(cfg *Config) {
+	cfg.RedisAddr = requireEnv("JUMP_REDIS_ADDR")
+	cfg.RedisUser = getenv("JUMP_REDIS_USERNAME", "default")
+	cfg.RedisPasswordRequired = mustBool("JUMP_REDIS_PASSWORD_REQUIRED", true)
+	cfg.RedisPassword = getenv("JUMP_REDIS_PASSWORD", "")
+	cfg.RedisDB = requireEnvInt("JUMP_REDIS_DB")
+	cfg.RedisDT = mustDuration("REDIS_DIAL_TIMEOUT", 5*time.Second)
+	cfg.RedisRT = mustDuration("REDIS_READ_TIMEOUT", 3*time.Second)
+	cfg.RedisWT = mustDuration("REDIS_WRITE_TIMEOUT", 3*time.Second)
+	cfg.RedisMaxWait = mustDuration("REDIS_MAX_WAIT", 10*time.Second)
+	cfg.RedisPingTimeout = mustDuration("REDIS_PING_TIMEOUT", 5*time.Second)
+	cfg.RedisPoolSize = getenvInt("REDIS_POOL_SIZE", 10)
+	cfg.RedisConnectTimeout = mustDuration("REDIS_CONNECT_TIMEOUT", 30*time.Second)
+	cfg.RedisRetryInterval = mustDuration("REDIS_RETRY_INTERVAL", 2*time.Second)
+	cfg.RedisWarnThreshold = getenvInt("REDIS_WARN_THRESHOLD", 3)
+
+	// Validate Redis password configuration
+	if cfg.RedisPasswordRequired && cfg.RedisPassword == "" {
+		panic("❌ FATAL: JUMP_REDIS_PASSWORD is required when JUMP_REDIS_PASSWORD_REQUIRED=true")
+	}
+}
+
 // helpers
 func getenv(key, def string) string {
 	if v := os.Getenv(key); v != "" {
@@ -153,6 +257,15 @@ func getenvInt(key string, def int) int {
 	return def
 }
 
+func getenvFloat(key string, def float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
 func mustBool(key string, def bool) bool {
 	if v := os.Getenv(key); v != "" {
 		b, err := strconv.ParseBool(v)
@@ -185,6 +298,32 @@ func parseAllowedIPs(allowed string) []string {
 	return ips
 }
 
+// parseSearchEngines parses whitespace-separated "name=template" pairs.
+// Templates are URLs, which may contain commas but never unescaped spaces.
+// Example: "ddg=https://duckduckgo.com/?q={q} gh=https://github.com/search?q={q}"
+func parseSearchEngines(s string) map[string]string {
+	engines := make(map[string]string)
+	for _, entry := range strings.Fields(s) {
+		entry = strings.Trim(entry, `"'`)
+		if entry == "" {
+			continue
+		}
+		name, template, ok := strings.Cut(entry, "=")
+		name = strings.ToLower(name)
+		if !ok || name == "" || template == "" {
+			panic(fmt.Sprintf("❌ FATAL: Invalid search engine %q in JUMP_SEARCH_ENGINES (expected name=template)", entry))
+		}
+		if name == domain.FeedbackBang {
+			panic(fmt.Sprintf("❌ FATAL: Invalid search engine %q in JUMP_SEARCH_ENGINES: name is reserved for feedback", name))
+		}
+		if err := domain.ValidateSearchTemplate(template); err != nil {
+			panic(fmt.Sprintf("❌ FATAL: Invalid search engine %q in JUMP_SEARCH_ENGINES: %v", name, err))
+		}
+		engines[name] = template
+	}
+	return engines
+}
+
 func splitAndTrim(s string) []string {
 	if s == "" {
 		return nil
@@ -202,6 +341,14 @@ func splitAndTrim(s string) []string {
 	return parts
 }
 
+// lowerAll lowercases every value
+func lowerAll(values []string) []string {
+	for i, v := range values {
+		values[i] = strings.ToLower(v)
+	}
+	return values
+}
+
 // extractDomains extracts domain suffixes from allowed hosts for redirect validation.
 // Examples: "jump.domain.ext" -> ["domain.ext", "jump.domain.ext"]
 //
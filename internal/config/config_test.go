@@ -286,6 +286,65 @@ func TestMustDuration(t *testing.T) {
 	}
 }
 
+func TestGetenvFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    string
+		def      float64
+		expected float64
+	}{
+		{
+			name:     "valid float",
+			key:      "TEST_FLOAT",
+			value:    "12.5",
+			def:      1,
+			expected: 12.5,
+		},
+		{
+			name:     "integer value",
+			key:      "TEST_FLOAT_INT",
+			value:    "0",
+			def:      10,
+			expected: 0,
+		},
+		{
+			name:     "invalid value uses default",
+			key:      "TEST_FLOAT_INVALID",
+			value:    "invalid",
+			def:      10,
+			expected: 10,
+		},
+		{
+			name:     "missing variable uses default",
+			key:      "TEST_FLOAT_MISSING",
+			value:    "",
+			def:      10,
+			expected: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.value != "" {
+				if err := os.Setenv(tt.key, tt.value); err != nil {
+					t.Fatalf("failed to set env var: %v", err)
+				}
+				defer func() {
+					if err := os.Unsetenv(tt.key); err != nil {
+						t.Errorf("failed to unset env var: %v", err)
+					}
+				}()
+			}
+
+			result := getenvFloat(tt.key, tt.def)
+			if result != tt.expected {
+				t.Errorf("getenvFloat() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestMustBool(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -344,3 +403,92 @@ func TestMustBool(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSearchEngines(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  map[string]string
+		wantPanic bool
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: map[string]string{},
+		},
+		{
+			name:  "multiple engines",
+			input: "ddg=https://duckduckgo.com/?q={q}  GH=https://github.com/search?q={q}&type=code",
+			expected: map[string]string{
+				"ddg": "https://duckduckgo.com/?q={q}",
+				"gh":  "https://github.com/search?q={q}&type=code",
+			},
+		},
+		{
+			name:  "commas in templates",
+			input: "\"maps=https://maps.example/?q={q}&ll=48.85,2.35\nwiki=https://en.wikipedia.org/w/index.php?search={q}\"",
+			expected: map[string]string{
+				"maps": "https://maps.example/?q={q}&ll=48.85,2.35",
+				"wiki": "https://en.wikipedia.org/w/index.php?search={q}",
+			},
+		},
+		{
+			name:      "missing template",
+			input:     "ddg",
+			wantPanic: true,
+		},
+		{
+			name:      "missing name",
+			input:     "=https://duckduckgo.com/?q={q}",
+			wantPanic: true,
+		},
+		{
+			name:      "reserved feedback name",
+			input:     "wrong=https://duckduckgo.com/?q={q}",
+			wantPanic: true,
+		},
+		{
+			name:      "template without placeholder",
+			input:     "ddg=https://duckduckgo.com/",
+			wantPanic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if (r != nil) != tt.wantPanic {
+					t.Errorf("parseSearchEngines() panic = %v, wantPanic %v", r, tt.wantPanic)
+				}
+			}()
+
+			result := parseSearchEngines(tt.input)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("parseSearchEngines() = %v, want %v", result, tt.expected)
+			}
+			for name, template := range tt.expected {
+				if result[name] != template {
+					t.Errorf("parseSearchEngines()[%q] = %q, want %q", name, result[name], template)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadRedis(t *testing.T) {
+	// Only the Redis settings are set: the server settings must not be required
+	t.Setenv("JUMP_HOMEPAGE_URL", "")
+	t.Setenv("JUMP_ALLOWED_HOSTS", "")
+	t.Setenv("JUMP_REDIS_ADDR", "localhost:6379")
+	t.Setenv("JUMP_REDIS_DB", "2")
+	t.Setenv("JUMP_REDIS_PASSWORD_REQUIRED", "false")
+
+	cfg := LoadRedis()
+	if cfg.RedisAddr != "localhost:6379" || cfg.RedisDB != 2 {
+		t.Errorf("LoadRedis() = addr %q, db %d", cfg.RedisAddr, cfg.RedisDB)
+	}
+	if cfg.RedisDT != 5*time.Second {
+		t.Errorf("LoadRedis() dial timeout = %v, want default 5s", cfg.RedisDT)
+	}
+}
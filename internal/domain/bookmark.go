@@ -18,10 +18,26 @@ type Bookmark struct {
 	// Example: "ChatGPT", "Docker Hub"
 	Abbr string
 
+	// Abbrs are all the abbreviations the bookmark answers to, Abbr first.
+	// Example: "GH", "git"
+	Abbrs []string
+
 	// URL is the full external URL to redirect to.
 	// Example: https://chat.openai.com/
 	URL string
 
+	// ─────────────────────────────
+	// Functional description
+	// ─────────────────────────────
+
+	// Name is the name the bookmark is listed under in Homepage.
+	// Example: Docker Hub
+	Name string
+
+	// Category is the Homepage category the bookmark belongs to.
+	// Example: Developer
+	Category string
+
 	// ─────────────────────────────
 	// Provenance & observation
 	// ─────────────────────────────
@@ -31,15 +47,25 @@ type Bookmark struct {
 	Sources []string
 
 	// ─────────────────────────────
-	// Metadata
+	// Learning & persistence
 	// ─────────────────────────────
 
+	// Counter represents the number of successful redirects.
+	Counter int64
+
+	// Frecency is the usage counter with exponential time decay,
+	// same as for services. It breaks ties between matching bookmarks.
+	Frecency float64
+
 	// CreatedAt is the first time the bookmark was discovered.
 	CreatedAt time.Time
 
 	// UpdatedAt is updated on any mutation.
 	UpdatedAt time.Time
 
+	// LastUsedAt is updated only after a successful redirect.
+	LastUsedAt time.Time
+
 	// ─────────────────────────────
 	// Liveness & cleanup
 	// ─────────────────────────────
@@ -48,3 +74,15 @@ type Bookmark struct {
 	// It may be garbage-collected later.
 	Disabled bool
 }
+
+// Abbreviations returns the abbreviations of the bookmark
+// (Abbr alone for bookmarks stored before Abbrs existed)
+func (b *Bookmark) Abbreviations() []string {
+	if len(b.Abbrs) > 0 {
+		return b.Abbrs
+	}
+	if b.Abbr == "" {
+		return nil
+	}
+	return []string{b.Abbr}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrBookmarkRejected is returned when a bookmark URL violates the bookmark policy
+var ErrBookmarkRejected = errors.New("bookmark URL rejected")
+
+// DefaultBookmarkSchemes are the URL schemes bookmarks may use when none are configured
+var DefaultBookmarkSchemes = []string{"http", "https"}
+
+// BookmarkPolicy restricts the URLs bookmarks may redirect to.
+// Bookmarks point outside the allowed domains of services,
+// so they get their own scheme and domain lists.
+type BookmarkPolicy struct {
+	// Schemes are the allowed URL schemes (empty = DefaultBookmarkSchemes).
+	Schemes []string
+
+	// AllowedDomains restricts bookmarks to these domains and their subdomains (empty = any domain).
+	AllowedDomains []string
+
+	// DeniedDomains rejects these domains and their subdomains, even when allowed.
+	DeniedDomains []string
+}
+
+// Check validates a bookmark URL against the policy.
+// The returned error wraps ErrBookmarkRejected and names the reason.
+func (p BookmarkPolicy) Check(rawURL string) error {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return fmt.Errorf("%w: invalid URL: %w", ErrBookmarkRejected, err)
+	}
+
+	schemes := p.Schemes
+	if len(schemes) == 0 {
+		schemes = DefaultBookmarkSchemes
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if !containsFold(schemes, scheme) {
+		return fmt.Errorf("%w: scheme %q is not allowed", ErrBookmarkRejected, scheme)
+	}
+
+	hostname := u.Hostname()
+	if MatchesDomain(hostname, p.DeniedDomains) {
+		return fmt.Errorf("%w: domain %q is denied", ErrBookmarkRejected, hostname)
+	}
+	if len(p.AllowedDomains) > 0 && !MatchesDomain(hostname, p.AllowedDomains) {
+		return fmt.Errorf("%w: domain %q is not allowed", ErrBookmarkRejected, hostname)
+	}
+
+	return nil
+}
+
+// MatchesDomain reports whether a hostname is one of the domains or a subdomain of one
+func MatchesDomain(hostname string, domains []string) bool {
+	hostname = strings.ToLower(hostname)
+	if hostname == "" {
+		return false
+	}
+
+	for _, domain := range domains {
+		domain = strings.ToLower(domain)
+
+		// Exact match
+		if hostname == domain {
+			return true
+		}
+
+		// Subdomain match (hostname ends with .domain)
+		if strings.HasSuffix(hostname, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
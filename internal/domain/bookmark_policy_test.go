@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBookmarkPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  BookmarkPolicy
+		url     string
+		wantErr bool
+	}{
+		{"https by default", BookmarkPolicy{}, "https://github.com", false},
+		{"http by default", BookmarkPolicy{}, "http://example.com", false},
+		{"javascript rejected", BookmarkPolicy{}, "javascript:alert(1)", true},
+		{"data rejected", BookmarkPolicy{}, "data:text/html,<script>alert(1)</script>", true},
+		{"scheme case ignored", BookmarkPolicy{}, "HTTPS://github.com", false},
+		{"custom scheme allowed", BookmarkPolicy{Schemes: []string{"https", "mailto"}}, "mailto:me@example.com", false},
+		{"http not in custom schemes", BookmarkPolicy{Schemes: []string{"https"}}, "http://example.com", true},
+		{"allowed domain", BookmarkPolicy{AllowedDomains: []string{"github.com"}}, "https://gist.github.com/x", false},
+		{"domain not allowed", BookmarkPolicy{AllowedDomains: []string{"github.com"}}, "https://githb.com", true},
+		{"suffix is not a subdomain", BookmarkPolicy{AllowedDomains: []string{"github.com"}}, "https://evilgithub.com", true},
+		{"denied domain", BookmarkPolicy{DeniedDomains: []string{"evil.example"}}, "https://www.evil.example", true},
+		{"deny wins over allow", BookmarkPolicy{AllowedDomains: []string{"example"}, DeniedDomains: []string{"evil.example"}}, "https://evil.example", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.url)
+			if tt.wantErr && !errors.Is(err, ErrBookmarkRejected) {
+				t.Errorf("Check(%q) error = %v, want ErrBookmarkRejected", tt.url, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Check(%q) error = %v, want nil", tt.url, err)
+			}
+		})
+	}
+}
@@ -1,49 +1,110 @@
 package domain
 
 import (
+	"math"
 	"strings"
 )
 
 // BookmarkCandidate represents a bookmark candidate with its match score
 type BookmarkCandidate struct {
-	Bookmark *Bookmark
-	Score    float64 // Score from fuzzy matching
+	Bookmark     *Bookmark
+	LexicalScore float64 // Score from fuzzy matching
+	UsageScore   float64 // Score from usage frecency
+	Score        float64 // Combined score
 }
 
-// ScoreBookmark calculates the match score for a bookmark against a query string
+// BookmarkCategorySeparator ends the category scope of a bookmark query ("dev/hub").
+// Bookmarks have no deep links, so unlike services they can use '/'.
+const BookmarkCategorySeparator = "/"
+
+// SplitBookmarkQuery splits a bookmark query into its category scope and search text:
+//   - "dev/hub" -> category "dev" + "hub"
+//   - "dev/" -> category "dev", every bookmark of the category
+//   - "hub" -> no category
+func SplitBookmarkQuery(query string) (category, search string) {
+	category, search, found := strings.Cut(query, BookmarkCategorySeparator)
+	if !found || strings.TrimSpace(category) == "" {
+		return "", strings.TrimSpace(query)
+	}
+	return strings.TrimSpace(category), strings.TrimSpace(search)
+}
+
+// ScoreBookmark calculates the match score for a bookmark against a query string.
+// Abbreviations score in full, the Homepage name and category are weighted like
+// the display name and group of services.
 func ScoreBookmark(queryStr string, bookmark *Bookmark) float64 {
 	if bookmark == nil || queryStr == "" {
 		return 0.0
 	}
 
 	queryStr = strings.ToLower(strings.TrimSpace(queryStr))
-	abbr := strings.ToLower(bookmark.Abbr)
+
+	var best float64
+	for _, abbr := range bookmark.Abbreviations() {
+		if score := scoreBookmarkText(queryStr, abbr); score > best {
+			best = score
+		}
+	}
+
+	// The name is matched as a whole ("docker hub") and word by word ("hub")
+	words := strings.Fields(queryStr)
+	name := math.Max(scoreBookmarkText(queryStr, bookmark.Name), scoreWords(words, splitWords(bookmark.Name), 0))
+	best = math.Max(best, name*ScoreDisplayNameWeight)
+
+	// Categories are matched on whole words or prefixes only
+	best = math.Max(best, scoreWords(words, splitWords(bookmark.Category), ScorePrefixMatch)*ScoreGroupWeight)
+
+	return best
+}
+
+// scoreBookmarkText scores a lowercased query against one bookmark field.
+// Single words score on the same scale as service names (see Score): the same
+// position bonus on prefixes and the same bonus for short names, so unified
+// rankings can compare both kinds.
+func scoreBookmarkText(queryStr, text string) float64 {
+	if queryStr == "" || text == "" {
+		return 0.0
+	}
+
+	text = strings.ToLower(text)
 
 	// Exact match (highest score)
-	if queryStr == abbr {
+	if queryStr == text {
 		return ScoreExactMatch + ScoreExactHostnameBonus
 	}
 
+	score := matchBookmarkText(queryStr, text)
+
+	// Only apply length bonus if there was a match
+	if score > 0 && len(normalizeFragment(text)) < 10 {
+		score += ScoreLengthBonus
+	}
+
+	return score
+}
+
+// matchBookmarkText scores a query against a lowercased field it is not equal to
+func matchBookmarkText(queryStr, text string) float64 {
 	// Prefix match
-	if strings.HasPrefix(abbr, queryStr) {
-		return ScorePrefixMatch
+	if strings.HasPrefix(text, queryStr) {
+		return ScorePrefixMatch + calculatePositionBonus(0)
 	}
 
 	// Substring match
-	if strings.Contains(abbr, queryStr) {
-		index := strings.Index(abbr, queryStr)
+	if strings.Contains(text, queryStr) {
+		index := strings.Index(text, queryStr)
 		// Earlier substring matches get higher score
-		substringBonus := ScorePositionBonus * (1.0 - float64(index)/float64(len(abbr)))
+		substringBonus := ScorePositionBonus * (1.0 - float64(index)/float64(len(text)))
 		return ScoreSubstringMatch + substringBonus
 	}
 
 	// Fuzzy match (word-based)
-	// Check if all query words appear in abbr
+	// Check if all query words appear in the text
 	queryWords := strings.Fields(queryStr)
 	if len(queryWords) > 1 {
 		allMatch := true
 		for _, word := range queryWords {
-			if !strings.Contains(abbr, word) {
+			if !strings.Contains(text, word) {
 				allMatch = false
 				break
 			}
@@ -53,17 +114,18 @@ func ScoreBookmark(queryStr string, bookmark *Bookmark) float64 {
 		}
 	}
 
-	// Character similarity
-	similarity := calculateSimilarity(queryStr, abbr)
-	if similarity > 0.5 {
-		return ScoreFuzzyMatch * similarity
-	}
-
-	return 0.0
+	// Acronym, subsequence or typo
+	return fuzzyScore(normalizeFragment(queryStr), text)
 }
 
-// RankBookmarkCandidates ranks bookmark candidates by score
+// RankBookmarkCandidates ranks bookmark candidates by score.
+// A "category/" prefix limits the ranking to the best matching category.
 func RankBookmarkCandidates(queryStr string, bookmarks []*Bookmark) []*BookmarkCandidate {
+	category, queryStr := SplitBookmarkQuery(queryStr)
+	if category != "" {
+		bookmarks = filterCategory(category, bookmarks)
+	}
+
 	candidates := make([]*BookmarkCandidate, 0, len(bookmarks))
 
 	for _, bookmark := range bookmarks {
@@ -74,14 +136,24 @@ func RankBookmarkCandidates(queryStr string, bookmarks []*Bookmark) []*BookmarkC
 
 		score := ScoreBookmark(queryStr, bookmark)
 
+		// A bare scope ("dev/") lists the whole category
+		if category != "" && queryStr == "" {
+			score = ScoreExactMatch * ScoreGroupWeight
+		}
+
 		// Skip bookmarks with zero score (no match)
 		if score == 0.0 {
 			continue
 		}
 
+		// Usage only reorders bookmarks that already match
+		usage := usageScore(bookmark.Frecency)
+
 		candidates = append(candidates, &BookmarkCandidate{
-			Bookmark: bookmark,
-			Score:    score,
+			Bookmark:     bookmark,
+			LexicalScore: score,
+			UsageScore:   usage,
+			Score:        score + usage,
 		})
 	}
 
@@ -91,6 +163,36 @@ func RankBookmarkCandidates(queryStr string, bookmarks []*Bookmark) []*BookmarkC
 	return candidates
 }
 
+// filterCategory keeps the bookmarks of the category that best matches the query scope
+// (categories are matched like service groups)
+func filterCategory(scope string, bookmarks []*Bookmark) []*Bookmark {
+	categoryScores := make(map[string]float64)
+	best := 0.0
+	for _, bookmark := range bookmarks {
+		if _, seen := categoryScores[bookmark.Category]; seen || bookmark.Category == "" {
+			continue
+		}
+		score := scoreFragment(scope, bookmark.Category, 0)
+		categoryScores[bookmark.Category] = score
+		if score > best {
+			best = score
+		}
+	}
+
+	// No category looks like the scope
+	if best == 0.0 {
+		return nil
+	}
+
+	filtered := make([]*Bookmark, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		if bookmark.Category != "" && categoryScores[bookmark.Category] == best {
+			filtered = append(filtered, bookmark)
+		}
+	}
+	return filtered
+}
+
 // sortBookmarkCandidates sorts candidates by score (descending)
 func sortBookmarkCandidates(candidates []*BookmarkCandidate) {
 	// Simple bubble sort (fine for small lists)
@@ -102,3 +102,105 @@ func TestRankBookmarkCandidates_DisabledFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestRankBookmarkCandidates_Usage(t *testing.T) {
+	bookmarks := []*Bookmark{
+		{ID: "github", Abbr: "GitHub", URL: "https://github.com"},
+		{ID: "gitlab", Abbr: "GitLab", URL: "https://gitlab.com", Frecency: 20},
+	}
+
+	candidates := RankBookmarkCandidates("git", bookmarks)
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %d", len(candidates))
+	}
+	if candidates[0].Bookmark.ID != "gitlab" {
+		t.Errorf("Expected the most used bookmark first, got %s", candidates[0].Bookmark.ID)
+	}
+	if candidates[0].UsageScore <= 0 || candidates[0].Score != candidates[0].LexicalScore+candidates[0].UsageScore {
+		t.Errorf("Unexpected score breakdown: %+v", candidates[0])
+	}
+
+	// Usage never turns a non-matching bookmark into a candidate
+	if got := RankBookmarkCandidates("xyz", bookmarks); len(got) != 0 {
+		t.Errorf("Expected no candidates, got %d", len(got))
+	}
+}
+
+func TestRankBookmarkCandidates_NameAndAbbrs(t *testing.T) {
+	bookmarks := []*Bookmark{
+		{ID: "dockerhub", Abbr: "DH", Abbrs: []string{"DH", "images"}, Name: "Docker Hub", Category: "Developer", URL: "https://hub.docker.com"},
+		{ID: "github", Abbr: "GH", Name: "GitHub", Category: "Developer", URL: "https://github.com"},
+		{ID: "reddit", Abbr: "RE", Name: "Reddit", Category: "Social", URL: "https://reddit.com"},
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"docker", "dockerhub"},
+		{"dh", "dockerhub"},
+		{"images", "dockerhub"},
+		{"gh", "github"},
+		{"social", "reddit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			candidates := RankBookmarkCandidates(tt.query, bookmarks)
+			if len(candidates) == 0 {
+				t.Fatalf("No candidates for %q", tt.query)
+			}
+			if candidates[0].Bookmark.ID != tt.want {
+				t.Errorf("Expected %s first for %q, got %s", tt.want, tt.query, candidates[0].Bookmark.ID)
+			}
+		})
+	}
+}
+
+func TestRankBookmarkCandidates_Category(t *testing.T) {
+	bookmarks := []*Bookmark{
+		{ID: "dockerhub", Abbr: "DH", Name: "Docker Hub", Category: "Developer"},
+		{ID: "github", Abbr: "GH", Name: "GitHub", Category: "Developer"},
+		{ID: "hubspot", Abbr: "HS", Name: "HubSpot", Category: "Work"},
+	}
+
+	candidates := RankBookmarkCandidates("dev/hub", bookmarks)
+	if len(candidates) == 0 || candidates[0].Bookmark.ID != "dockerhub" {
+		t.Fatalf("Expected dockerhub first for dev/hub, got %v", candidates)
+	}
+	for _, c := range candidates {
+		if c.Bookmark.Category != "Developer" {
+			t.Errorf("dev/hub should stay in Developer, got %s", c.Bookmark.ID)
+		}
+	}
+
+	// A bare scope lists the whole category
+	if got := RankBookmarkCandidates("dev/", bookmarks); len(got) != 2 {
+		t.Errorf("Expected 2 candidates for dev/, got %d", len(got))
+	}
+
+	// An unknown category matches nothing
+	if got := RankBookmarkCandidates("zzz/hub", bookmarks); len(got) != 0 {
+		t.Errorf("Expected no candidates for an unknown category, got %d", len(got))
+	}
+}
+
+func TestSplitBookmarkQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		category string
+		search   string
+	}{
+		{"hub", "", "hub"},
+		{"dev/hub", "dev", "hub"},
+		{"dev/", "dev", ""},
+		{"/hub", "", "/hub"},
+	}
+
+	for _, tt := range tests {
+		category, search := SplitBookmarkQuery(tt.query)
+		if category != tt.category || search != tt.search {
+			t.Errorf("SplitBookmarkQuery(%q) = (%q, %q), want (%q, %q)", tt.query, category, search, tt.category, tt.search)
+		}
+	}
+}
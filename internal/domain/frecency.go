@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// MinFrecency is the value under which a decayed frecency is reset to zero
+const MinFrecency = 0.01
+
+// DecayFactor returns the multiplier applied to frecency after elapsed time,
+// so that usage loses half of its weight every halfLife (1 when decay is disabled)
+func DecayFactor(elapsed, halfLife time.Duration) float64 {
+	if halfLife <= 0 || elapsed <= 0 {
+		return 1.0
+	}
+	return math.Exp2(-float64(elapsed) / float64(halfLife))
+}
+
+// RecordUse counts a successful redirect to the service
+func (s *Service) RecordUse(now time.Time) {
+	s.Counter++
+	s.Frecency++
+	s.LastUsedAt = now
+}
+
+// Decay applies a decay factor to the service frecency
+func (s *Service) Decay(factor float64) {
+	s.Frecency = DecayValue(s.Frecency, factor)
+}
+
+// RecordUse counts a successful redirect to the bookmark
+func (b *Bookmark) RecordUse(now time.Time) {
+	b.Counter++
+	b.Frecency++
+	b.LastUsedAt = now
+}
+
+// Decay applies a decay factor to the bookmark frecency
+func (b *Bookmark) Decay(factor float64) {
+	b.Frecency = DecayValue(b.Frecency, factor)
+}
+
+// DecayValue applies a decay factor to a frecency value, dropping negligible leftovers
+func DecayValue(value, factor float64) float64 {
+	value *= factor
+	if value < MinFrecency {
+		return 0
+	}
+	return value
+}
+
+// MergeUsage adds the usage recorded by another copy of the service,
+// e.g. one imported from a snapshot of another instance
+func (s *Service) MergeUsage(other *Service) {
+	s.Counter += other.Counter
+	s.Frecency += other.Frecency
+	s.LastUsedAt = latestTime(s.LastUsedAt, other.LastUsedAt)
+	s.CreatedAt = earliestTime(s.CreatedAt, other.CreatedAt)
+}
+
+// MergeUsage adds the usage recorded by another copy of the bookmark
+func (b *Bookmark) MergeUsage(other *Bookmark) {
+	b.Counter += other.Counter
+	b.Frecency += other.Frecency
+	b.LastUsedAt = latestTime(b.LastUsedAt, other.LastUsedAt)
+	b.CreatedAt = earliestTime(b.CreatedAt, other.CreatedAt)
+}
+
+func latestTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// earliestTime ignores zero times, which mean "unknown"
+func earliestTime(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
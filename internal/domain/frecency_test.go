@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDecayFactor(t *testing.T) {
+	halfLife := 14 * 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		halfLife time.Duration
+		want     float64
+	}{
+		{"one half-life", halfLife, halfLife, 0.5},
+		{"two half-lives", 2 * halfLife, halfLife, 0.25},
+		{"no time elapsed", 0, halfLife, 1},
+		{"decay disabled", halfLife, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecayFactor(tt.elapsed, tt.halfLife); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("DecayFactor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceRecordUseAndDecay(t *testing.T) {
+	now := time.Now()
+	service := &Service{ID: "jellyfin.example.com", Hostname: "jellyfin.example.com"}
+
+	service.RecordUse(now)
+	service.RecordUse(now)
+
+	if service.Counter != 2 || service.Frecency != 2 {
+		t.Errorf("Expected counter and frecency of 2, got %d and %.2f", service.Counter, service.Frecency)
+	}
+	if !service.LastUsedAt.Equal(now) {
+		t.Errorf("Expected LastUsedAt to be set, got %v", service.LastUsedAt)
+	}
+
+	service.Decay(0.5)
+	if service.Frecency != 1 || service.Counter != 2 {
+		t.Errorf("Expected decay to halve frecency only, got counter %d and frecency %.2f", service.Counter, service.Frecency)
+	}
+
+	service.Decay(0.001)
+	if service.Frecency != 0 {
+		t.Errorf("Expected negligible frecency to be reset, got %.4f", service.Frecency)
+	}
+}
+
+func TestRankCandidates_FrecencyBeatsOldUsage(t *testing.T) {
+	halfLife := 14 * 24 * time.Hour
+	services := []*Service{
+		// 500 uses a year ago
+		{ID: "old.example.com", Hostname: "jellyold.example.com", Name: "jellyold", Counter: 500,
+			Frecency: 500 * DecayFactor(365*24*time.Hour, halfLife)},
+		// 20 uses this week
+		{ID: "new.example.com", Hostname: "jellynew.example.com", Name: "jellynew", Counter: 20,
+			Frecency: 20 * DecayFactor(3*24*time.Hour, halfLife)},
+	}
+
+	candidates := RankCandidates(ParseQuery("jelly"), services)
+	if candidates[0].Service.ID != "new.example.com" {
+		t.Errorf("Expected recent usage to win, got %s", candidates[0].Service.ID)
+	}
+}
+
+func TestServiceMergeUsage(t *testing.T) {
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	service := &Service{ID: "jellyfin.example.com", Counter: 3, Frecency: 1.5, LastUsedAt: older, CreatedAt: newer}
+	service.MergeUsage(&Service{ID: "jellyfin.example.com", Counter: 2, Frecency: 0.5, LastUsedAt: newer, CreatedAt: older})
+
+	if service.Counter != 5 || service.Frecency != 2 {
+		t.Errorf("Expected summed counter and frecency (5, 2), got %d and %.2f", service.Counter, service.Frecency)
+	}
+	if !service.LastUsedAt.Equal(newer) {
+		t.Errorf("Expected latest LastUsedAt, got %v", service.LastUsedAt)
+	}
+	if !service.CreatedAt.Equal(older) {
+		t.Errorf("Expected earliest CreatedAt, got %v", service.CreatedAt)
+	}
+
+	// Unknown creation dates are ignored
+	service.MergeUsage(&Service{})
+	if !service.CreatedAt.Equal(older) || !service.LastUsedAt.Equal(newer) {
+		t.Errorf("Expected zero times to be ignored, got created %v and last used %v", service.CreatedAt, service.LastUsedAt)
+	}
+}
+
+func TestBookmarkMergeUsage(t *testing.T) {
+	now := time.Now()
+	bookmark := &Bookmark{ID: "gh", Counter: 1, Frecency: 1}
+	bookmark.MergeUsage(&Bookmark{ID: "gh", Counter: 4, Frecency: 2, LastUsedAt: now, CreatedAt: now})
+
+	if bookmark.Counter != 5 || bookmark.Frecency != 3 {
+		t.Errorf("Expected summed counter and frecency (5, 3), got %d and %.2f", bookmark.Counter, bookmark.Frecency)
+	}
+	if !bookmark.LastUsedAt.Equal(now) || !bookmark.CreatedAt.Equal(now) {
+		t.Errorf("Expected imported timestamps to fill unknown ones, got %v and %v", bookmark.LastUsedAt, bookmark.CreatedAt)
+	}
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// Fuzzy measures, used when the query is neither a prefix nor a substring.
+	// Acronyms are a deliberate shorthand and outrank substrings, the others don't.
+	ScoreAcronymMatch     = 60.0 // "ha" -> home-assistant
+	ScoreSubsequenceMatch = 40.0 // "nc" -> nextcloud (scaled by match quality)
+	ScoreTypoMatch        = 45.0 // "jellyfni" -> jellyfin (scaled by similarity)
+
+	// Minimum edit similarity for a typo to count as a match
+	TypoSimilarityThreshold = 0.75
+)
+
+// fuzzyScore scores a normalized query against a target that neither starts with nor contains it.
+// The target is given raw so that its word boundaries can be used for acronyms.
+// Acronym, ordered subsequence and typo (Damerau-Levenshtein) measures are tried, the best wins.
+func fuzzyScore(query, target string) float64 {
+	normalized := normalizeFragment(target)
+	if query == "" || normalized == "" {
+		return 0.0
+	}
+
+	best := acronymScore(query, splitWords(target))
+	if score := subsequenceScore(query, normalized); score > best {
+		best = score
+	}
+	if score := typoScore(query, normalized); score > best {
+		best = score
+	}
+	return best
+}
+
+// acronymScore matches the query against the initials of the target words
+// ("ha" -> "home-assistant", "uk" -> "uptime kuma")
+func acronymScore(query string, words []string) float64 {
+	if len(words) < 2 || len([]rune(query)) < 2 {
+		return 0.0
+	}
+
+	var initials strings.Builder
+	for _, word := range words {
+		initials.WriteRune([]rune(word)[0])
+	}
+
+	switch {
+	case query == initials.String():
+		return ScoreAcronymMatch
+	case strings.HasPrefix(initials.String(), query):
+		// Leading initials only ("ha" -> "home-assistant-dev")
+		return ScoreAcronymMatch * 0.8
+	default:
+		return 0.0
+	}
+}
+
+// subsequenceScore matches the query characters in order in the target ("nc" -> "nextcloud").
+// Contiguous runs and a match on the first character score higher.
+func subsequenceScore(query, target string) float64 {
+	q := []rune(query)
+	t := []rune(target)
+	if len(q) < 2 || len(q) > len(t) {
+		return 0.0
+	}
+
+	runs := 0
+	start := -1
+	last := -2
+	i := 0
+	for j := 0; j < len(t) && i < len(q); j++ {
+		if t[j] != q[i] {
+			continue
+		}
+		if start < 0 {
+			start = j
+		}
+		if j != last+1 {
+			runs++
+		}
+		last = j
+		i++
+	}
+	if i < len(q) {
+		return 0.0
+	}
+
+	// Each gap between runs costs as much as a matched character is worth
+	quality := float64(len(q)) / float64(len(q)+runs-1)
+	if start > 0 {
+		quality *= 0.8
+	}
+
+	return ScoreSubsequenceMatch * quality
+}
+
+// typoScore matches the query against the target, or its beginning, allowing
+// a few insertions, deletions, substitutions and transpositions ("jellyfni" -> "jellyfin")
+func typoScore(query, target string) float64 {
+	q := []rune(query)
+	t := []rune(target)
+	if len(q) < 3 {
+		return 0.0
+	}
+
+	best := editSimilarity(q, t)
+
+	// Partial queries are compared to the target prefixes of about the same length
+	for n := len(q) - 1; n <= len(q)+1; n++ {
+		if n <= 0 || n >= len(t) {
+			continue
+		}
+		if similarity := editSimilarity(q, t[:n]); similarity > best {
+			best = similarity
+		}
+	}
+
+	if best < TypoSimilarityThreshold {
+		return 0.0
+	}
+	return ScoreTypoMatch * best
+}
+
+// editSimilarity converts the Damerau-Levenshtein distance into a similarity between 0 and 1
+func editSimilarity(a, b []rune) float64 {
+	longest := max(len(a), len(b))
+	if longest == 0 {
+		return 0.0
+	}
+	return 1.0 - float64(damerauLevenshtein(a, b))/float64(longest)
+}
+
+// damerauLevenshtein returns the optimal string alignment distance between two strings:
+// the number of insertions, deletions, substitutions and adjacent transpositions needed
+func damerauLevenshtein(a, b []rune) int {
+	// d[i][j] is the distance between a[:i] and b[:j]
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(
+				d[i-1][j]+1,      // deletion
+				d[i][j-1]+1,      // insertion
+				d[i-1][j-1]+cost, // substitution
+			)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1) // transposition
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
+
+// splitWords splits a name or hostname fragment on every non alphanumeric character
+// ("home-assistant" -> ["home", "assistant"])
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,118 @@
+package domain
+
+import "testing"
+
+func TestDamerauLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"jellyfin", "jellyfin", 0},
+		{"jellyfni", "jellyfin", 1}, // transposition
+		{"jelyfin", "jellyfin", 1},  // deletion
+		{"jellyfim", "jellyfin", 1}, // substitution
+		{"", "abc", 3},
+		{"traefik", "trfk", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := damerauLevenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+				t.Errorf("damerauLevenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		target         string
+		expectPositive bool
+	}{
+		{"subsequence", "nc", "nextcloud", true},
+		{"subsequence with gaps", "trfk", "traefik", true},
+		{"transposition", "jellyfni", "jellyfin", true},
+		{"typo in partial query", "jelyf", "jellyfin", true},
+		{"acronym", "ha", "home-assistant", true},
+		{"leading initials", "ha", "home-assistant-dev", true},
+		{"acronym across spaces", "dh", "Docker Hub", true},
+		{"out of order characters", "cn", "nextcloud", false},
+		{"unrelated", "xyz", "jellyfin", false},
+		{"unrelated typo length", "jellyfin", "jackett", false},
+		{"single character", "x", "nextcloud", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := fuzzyScore(tt.query, tt.target)
+
+			if tt.expectPositive && score <= 0 {
+				t.Errorf("Expected positive score for %q -> %q, got %f", tt.query, tt.target, score)
+			}
+
+			if !tt.expectPositive && score > 0 {
+				t.Errorf("Expected zero score for %q -> %q, got %f", tt.query, tt.target, score)
+			}
+		})
+	}
+}
+
+func TestFuzzyScore_Ordering(t *testing.T) {
+	// Contiguous subsequences beat scattered ones
+	if fuzzyScore("nxt", "nextcloud") <= fuzzyScore("ncd", "nextcloud") {
+		t.Error("Expected a contiguous subsequence to score higher")
+	}
+
+	// A full acronym beats a plain subsequence
+	if fuzzyScore("ha", "home-assistant") <= subsequenceScore("ha", "homeassistant") {
+		t.Error("Expected acronym to score higher than subsequence")
+	}
+
+	// Subsequences and typos never beat a substring match (acronyms may, but not a prefix)
+	if fuzzyScore("ha", "home-assistant") >= ScorePrefixMatch {
+		t.Error("Expected acronym to stay below prefix matches")
+	}
+	for _, target := range []string{"nextcloud", "jellyfin"} {
+		for _, query := range []string{"nc", "jellyfni", "jlfn"} {
+			if score := fuzzyScore(query, target); score >= ScoreSubstringMatch+ScorePositionBonus {
+				t.Errorf("fuzzyScore(%q, %q) = %.2f, should stay below substring matches", query, target, score)
+			}
+		}
+	}
+}
+
+func TestRankCandidates_Fuzzy(t *testing.T) {
+	services := []*Service{
+		{ID: "nextcloud", Hostname: "nextcloud.example.com", Name: "nextcloud"},
+		{ID: "jellyfin", Hostname: "jellyfin.example.com", Name: "jellyfin"},
+		{ID: "jellyseerr", Hostname: "jellyseerr.example.com", Name: "jellyseerr"},
+		{ID: "jackett", Hostname: "jackett.example.com", Name: "jackett"},
+		{ID: "homeassistant", Hostname: "hass.example.com", Name: "hass", DisplayName: "Home Assistant"},
+		{ID: "uptime", Hostname: "uptime-kuma.example.com", Name: "uptime-kuma"},
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"nc", "nextcloud"},
+		{"jellyfni", "jellyfin"},
+		{"jelyfin", "jellyfin"},
+		{"ha", "homeassistant"},
+		{"uk", "uptime"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			candidates := RankCandidates(ParseQuery(tt.query), services)
+			if len(candidates) == 0 {
+				t.Fatalf("No candidates for %q", tt.query)
+			}
+			if candidates[0].Service.ID != tt.want {
+				t.Errorf("Expected %s first for %q, got %s", tt.want, tt.query, candidates[0].Service.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Health is the last observed liveness of a service, as recorded by the background prober.
+type Health struct {
+	// ServiceID is the ID of the probed service.
+	ServiceID string
+
+	// Up is true when the last probe succeeded.
+	Up bool
+
+	// Latency is the duration of the last probe.
+	Latency time.Duration
+
+	// LastError is the error of the last probe (empty when up).
+	LastError string
+
+	// CheckedAt is the time of the last probe.
+	CheckedAt time.Time
+}
+
+// IsFresh reports whether the health data is recent enough to be trusted
+func (h *Health) IsFresh(now time.Time, maxAge time.Duration) bool {
+	if h == nil || h.CheckedAt.IsZero() || maxAge <= 0 {
+		return false
+	}
+	return now.Sub(h.CheckedAt) <= maxAge
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHealthIsFresh(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		health *Health
+		maxAge time.Duration
+		want   bool
+	}{
+		{"nil health", nil, time.Minute, false},
+		{"never checked", &Health{}, time.Minute, false},
+		{"recent", &Health{CheckedAt: now.Add(-30 * time.Second)}, time.Minute, true},
+		{"stale", &Health{CheckedAt: now.Add(-2 * time.Minute)}, time.Minute, false},
+		{"freshness disabled", &Health{CheckedAt: now}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.health.IsFresh(now, tt.maxAge); got != tt.want {
+				t.Errorf("IsFresh() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "sort"
+
+// Kinds of ranked results
+const (
+	MatchKindService  = "service"
+	MatchKindBookmark = "bookmark"
+)
+
+// ScoreBookmarkTypeWeight scales bookmark scores in unified rankings,
+// so a local service wins over a bookmark matching the query just as well
+const ScoreBookmarkTypeWeight = 0.9
+
+// Match is a ranked result of any kind in a unified ranking:
+// a service or a bookmark candidate, with its score on a common scale
+type Match struct {
+	Kind     string
+	Service  *Candidate         // set when Kind is MatchKindService
+	Bookmark *BookmarkCandidate // set when Kind is MatchKindBookmark
+	Score    float64            // total score, type weight applied
+}
+
+// ServiceMatch wraps a service candidate (scored in full)
+func ServiceMatch(candidate *Candidate) *Match {
+	return &Match{
+		Kind:    MatchKindService,
+		Service: candidate,
+		Score:   candidate.TotalScore,
+	}
+}
+
+// BookmarkMatch wraps a bookmark candidate (scored with ScoreBookmarkTypeWeight)
+func BookmarkMatch(candidate *BookmarkCandidate) *Match {
+	return &Match{
+		Kind:     MatchKindBookmark,
+		Bookmark: candidate,
+		Score:    candidate.Score * ScoreBookmarkTypeWeight,
+	}
+}
+
+// ID returns the ID of the matched service or bookmark
+func (m *Match) ID() string {
+	if m.Kind == MatchKindBookmark {
+		return m.Bookmark.Bookmark.ID
+	}
+	return m.Service.Service.ID
+}
+
+// MergeMatches ranks service and bookmark candidates together by score.
+// Services come first on equal scores.
+func MergeMatches(services []*Candidate, bookmarks []*BookmarkCandidate) []*Match {
+	matches := make([]*Match, 0, len(services)+len(bookmarks))
+	for _, candidate := range services {
+		matches = append(matches, ServiceMatch(candidate))
+	}
+	for _, candidate := range bookmarks {
+		matches = append(matches, BookmarkMatch(candidate))
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreScaleConsistency(t *testing.T) {
+	// A bookmark abbreviation and a service name equal to it score the same
+	names := []string{"grafana", "nextcloud", "jellyfin"}
+	queries := []string{"grafana", "graf", "fana", "grfana", "nc", "jel", "jellyfni"}
+
+	for _, name := range names {
+		service := &Service{ID: name + ".domain.ext", Hostname: name + ".domain.ext", Name: name}
+		bookmark := &Bookmark{ID: name, Abbr: name}
+		for _, query := range queries {
+			serviceScore := Score(ParseQuery(query), service)
+			bookmarkScore := ScoreBookmark(query, bookmark)
+			if math.Abs(serviceScore-bookmarkScore) > 1e-9 {
+				t.Errorf("%q against %q: service %.2f, bookmark %.2f", query, name, serviceScore, bookmarkScore)
+			}
+		}
+	}
+}
+
+func TestMergeMatches(t *testing.T) {
+	services := []*Candidate{
+		{Service: &Service{ID: "grafana.domain.ext"}, TotalScore: 100},
+		{Service: &Service{ID: "gitea.domain.ext"}, TotalScore: 40},
+	}
+	bookmarks := []*BookmarkCandidate{
+		{Bookmark: &Bookmark{ID: "github"}, Score: 200},
+		{Bookmark: &Bookmark{ID: "gitlab"}, Score: 100},
+	}
+
+	matches := MergeMatches(services, bookmarks)
+
+	want := []string{"github", "grafana.domain.ext", "gitlab", "gitea.domain.ext"}
+	if len(matches) != len(want) {
+		t.Fatalf("MergeMatches() returned %d matches, want %d", len(matches), len(want))
+	}
+	for i, id := range want {
+		if matches[i].ID() != id {
+			t.Errorf("matches[%d] = %s, want %s", i, matches[i].ID(), id)
+		}
+	}
+
+	if matches[2].Kind != MatchKindBookmark || matches[2].Score != 100*ScoreBookmarkTypeWeight {
+		t.Errorf("bookmark match should carry the type weight: %+v", matches[2])
+	}
+}
+
+func TestMergeMatches_ServiceWinsTie(t *testing.T) {
+	services := []*Candidate{{Service: &Service{ID: "grafana.domain.ext"}, TotalScore: 90}}
+	bookmarks := []*BookmarkCandidate{{Bookmark: &Bookmark{ID: "grafana"}, Score: 100}}
+
+	matches := MergeMatches(services, bookmarks)
+	if matches[0].Kind != MatchKindService {
+		t.Errorf("Expected the service to win the tie, got %s", matches[0].ID())
+	}
+}
@@ -5,13 +5,21 @@ import (
 	"unicode"
 )
 
+// pathSeparators start the deep-link suffix of a query
+const pathSeparators = "/?#"
+
+// GroupSeparator ends the group scope prefix of a query ("media:jel")
+const GroupSeparator = ":"
+
 // Query represents a parsed user input
 type Query struct {
-	Raw                string   // Original input
+	Raw                string   // Normalized search text (without the path suffix)
+	Group              string   // Homepage group the search is limited to (empty if none)
 	Fragments          []string // Space-separated fragments
 	HasDot             bool     // Whether input contains dot (enables subdomain matching)
 	TopLevelFragments  []string // Fragments before first dot (or all if no dot)
 	SubdomainFragments []string // Fragments after first dot (empty if no dot)
+	Path               string   // Deep-link suffix appended to the target, case preserved (empty if none)
 }
 
 // ParseQuery parses user input into a structured query
@@ -19,16 +27,32 @@ type Query struct {
 //   - "jelly pro" -> top-level only, unordered: ["jelly", "pro"]
 //   - "jelly.prod" -> subdomain enabled, ordered: ["jelly"] + ["prod"]
 //   - "jelly.srv sta" -> subdomain enabled: ["jelly", "srv"] + unordered ["sta"]
+//
+// A leading "group:" limits the search to the services of a Homepage group:
+//   - "monitoring:grafana" -> group "monitoring" + ["grafana"]
+//
+// The first '/', '?' or '#' starts the deep-link path: everything from it
+// onwards is kept verbatim in Path and never used for matching.
+//   - "graf /d/node-exporter" -> ["graf"] + path "/d/node-exporter"
+//   - "jel/web/index.html#!/search" -> ["jel"] + path "/web/index.html#!/search"
 func ParseQuery(input string) *Query {
+	input, path := splitPath(strings.TrimSpace(input))
+
 	// Normalize input
 	input = strings.TrimSpace(strings.ToLower(input))
 	if input == "" {
-		return &Query{Raw: input}
+		return &Query{Raw: input, Path: path}
 	}
 
 	q := &Query{
-		Raw:    input,
-		HasDot: strings.Contains(input, "."),
+		Raw:  input,
+		Path: path,
+	}
+
+	q.Group, input = splitGroup(input)
+	q.HasDot = strings.Contains(input, ".")
+	if input == "" {
+		return q
 	}
 
 	if !q.HasDot {
@@ -64,6 +88,39 @@ func ParseQuery(input string) *Query {
 	return q
 }
 
+// splitPath separates the search text from the deep-link path suffix
+func splitPath(input string) (search, path string) {
+	if i := strings.IndexAny(input, pathSeparators); i >= 0 {
+		return input[:i], input[i:]
+	}
+	return input, ""
+}
+
+// splitGroup separates the group scope prefix from the search text.
+// Host and port pairs are not groups: the prefix of "nas.lan:5001" has a dot
+// and the suffix of "nas:5001" is a port.
+func splitGroup(input string) (group, search string) {
+	group, search, found := strings.Cut(input, GroupSeparator)
+	group, search = strings.TrimSpace(group), strings.TrimSpace(search)
+	if !found || group == "" || strings.Contains(group, ".") || isPort(search) {
+		return "", input
+	}
+	return group, search
+}
+
+// isPort reports whether s is made of digits only
+func isPort(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // splitAndClean splits a string by separator and returns non-empty parts
 func splitAndClean(s, sep string) []string {
 	parts := strings.Split(s, sep)
@@ -78,6 +78,164 @@ func TestParseQuery(t *testing.T) {
 	}
 }
 
+func TestParseQueryPath(t *testing.T) {
+	tests := []struct {
+		name              string
+		input             string
+		expectedRaw       string
+		expectedFragments []string
+		expectedHasDot    bool
+		expectedPath      string
+	}{
+		{
+			name:              "no path",
+			input:             "graf",
+			expectedRaw:       "graf",
+			expectedFragments: []string{"graf"},
+			expectedPath:      "",
+		},
+		{
+			name:              "path after space",
+			input:             "graf /d/node-exporter",
+			expectedRaw:       "graf",
+			expectedFragments: []string{"graf"},
+			expectedPath:      "/d/node-exporter",
+		},
+		{
+			name:              "path glued to fragment keeps dots and case",
+			input:             "Jel/web/Index.html#!/search",
+			expectedRaw:       "jel",
+			expectedFragments: []string{"jel"},
+			expectedPath:      "/web/Index.html#!/search",
+		},
+		{
+			name:              "subdomain query with path",
+			input:             "adg.home /login",
+			expectedRaw:       "adg.home",
+			expectedFragments: []string{"adg", "home"},
+			expectedHasDot:    true,
+			expectedPath:      "/login",
+		},
+		{
+			name:              "query string only",
+			input:             "graf?orgId=1",
+			expectedRaw:       "graf",
+			expectedFragments: []string{"graf"},
+			expectedPath:      "?orgId=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := ParseQuery(tt.input)
+
+			if query.Raw != tt.expectedRaw {
+				t.Errorf("Raw = %q, want %q", query.Raw, tt.expectedRaw)
+			}
+
+			if query.HasDot != tt.expectedHasDot {
+				t.Errorf("HasDot = %v, want %v", query.HasDot, tt.expectedHasDot)
+			}
+
+			if !slicesEqual(query.Fragments, tt.expectedFragments) {
+				t.Errorf("Fragments = %v, want %v", query.Fragments, tt.expectedFragments)
+			}
+
+			if query.Path != tt.expectedPath {
+				t.Errorf("Path = %q, want %q", query.Path, tt.expectedPath)
+			}
+		})
+	}
+}
+
+func TestParseQueryGroup(t *testing.T) {
+	tests := []struct {
+		name              string
+		input             string
+		expectedRaw       string
+		expectedGroup     string
+		expectedFragments []string
+		expectedHasDot    bool
+		expectedPath      string
+	}{
+		{
+			name:              "no group",
+			input:             "grafana",
+			expectedRaw:       "grafana",
+			expectedFragments: []string{"grafana"},
+		},
+		{
+			name:              "group scope",
+			input:             "Monitoring:grafana",
+			expectedRaw:       "monitoring:grafana",
+			expectedGroup:     "monitoring",
+			expectedFragments: []string{"grafana"},
+		},
+		{
+			name:              "group with spaces, subdomain and path",
+			input:             "home lab : adg.ha /login",
+			expectedRaw:       "home lab : adg.ha",
+			expectedGroup:     "home lab",
+			expectedFragments: []string{"adg", "ha"},
+			expectedHasDot:    true,
+			expectedPath:      "/login",
+		},
+		{
+			name:          "bare group",
+			input:         "media:",
+			expectedRaw:   "media:",
+			expectedGroup: "media",
+		},
+		{
+			name:              "host and port is not a group",
+			input:             "nas.lan:5001",
+			expectedRaw:       "nas.lan:5001",
+			expectedFragments: []string{"nas", "lan:5001"},
+			expectedHasDot:    true,
+		},
+		{
+			name:              "port with a path is not a group",
+			input:             "host:8080/path",
+			expectedRaw:       "host:8080",
+			expectedFragments: []string{"host:8080"},
+			expectedPath:      "/path",
+		},
+		{
+			name:              "colon in path is not a group",
+			input:             "graf?from=now:1h",
+			expectedRaw:       "graf",
+			expectedFragments: []string{"graf"},
+			expectedPath:      "?from=now:1h",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := ParseQuery(tt.input)
+
+			if query.Raw != tt.expectedRaw {
+				t.Errorf("Raw = %q, want %q", query.Raw, tt.expectedRaw)
+			}
+
+			if query.Group != tt.expectedGroup {
+				t.Errorf("Group = %q, want %q", query.Group, tt.expectedGroup)
+			}
+
+			if query.HasDot != tt.expectedHasDot {
+				t.Errorf("HasDot = %v, want %v", query.HasDot, tt.expectedHasDot)
+			}
+
+			if !slicesEqual(query.Fragments, tt.expectedFragments) {
+				t.Errorf("Fragments = %v, want %v", query.Fragments, tt.expectedFragments)
+			}
+
+			if query.Path != tt.expectedPath {
+				t.Errorf("Path = %q, want %q", query.Path, tt.expectedPath)
+			}
+		})
+	}
+}
+
 func slicesEqual(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -121,6 +279,30 @@ func TestScore(t *testing.T) {
 			hostname:       "jellyfin.production.domain.ext",
 			expectPositive: true,
 		},
+		{
+			name:           "transposition typo",
+			queryStr:       "jellyfni",
+			hostname:       "jellyfin.domain.ext",
+			expectPositive: true,
+		},
+		{
+			name:           "ordered subsequence",
+			queryStr:       "nc",
+			hostname:       "nextcloud.domain.ext",
+			expectPositive: true,
+		},
+		{
+			name:           "acronym across word boundaries",
+			queryStr:       "ha",
+			hostname:       "home-assistant.domain.ext",
+			expectPositive: true,
+		},
+		{
+			name:           "characters out of order",
+			queryStr:       "cn",
+			hostname:       "nextcloud.domain.ext",
+			expectPositive: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -185,3 +367,275 @@ func TestRankCandidates_DisabledFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestRankCandidates_Aliases(t *testing.T) {
+	services := []*Service{
+		{ID: "adguard.example.com", Hostname: "adguard.example.com", Name: "adguard", Aliases: []string{"dns", "adblock"}},
+		{ID: "dnsmasq.example.com", Hostname: "dnsmasq.example.com", Name: "dnsmasq"},
+	}
+
+	candidates := RankCandidates(ParseQuery("dns"), services)
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %d", len(candidates))
+	}
+
+	if candidates[0].Service.ID != "adguard.example.com" {
+		t.Errorf("Expected alias to win as exact match, got %s", candidates[0].Service.ID)
+	}
+	if candidates[0].LexicalScore != ScoreExactMatch+ScoreExactHostnameBonus {
+		t.Errorf("Expected alias to score as exact match, got %.2f", candidates[0].LexicalScore)
+	}
+
+	// Aliases are ignored for subdomain queries
+	if score := Score(ParseQuery("dns.prod"), services[0]); score != 0 {
+		t.Errorf("Expected no alias match for dotted query, got %.2f", score)
+	}
+}
+
+func TestRankCandidates_Boost(t *testing.T) {
+	services := []*Service{
+		{ID: "jellyfin.example.com", Hostname: "jellyfin.example.com", Name: "jellyfin"},
+		{ID: "jellyseerr.example.com", Hostname: "jellyseerr.example.com", Name: "jellyseerr", Boost: 20},
+	}
+
+	candidates := RankCandidates(ParseQuery("jel"), services)
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %d", len(candidates))
+	}
+
+	if candidates[0].Service.ID != "jellyseerr.example.com" {
+		t.Errorf("Expected boosted service first, got %s", candidates[0].Service.ID)
+	}
+	if candidates[0].BoostScore != 20 || candidates[0].TotalScore != candidates[0].LexicalScore+20 {
+		t.Errorf("Expected boost in total score, got %+v", candidates[0])
+	}
+}
+
+func TestRankCandidates_Pinned(t *testing.T) {
+	services := []*Service{
+		{ID: "jellyfin.example.com", Hostname: "jellyfin.example.com", Name: "jellyfin"},
+		{ID: "jellyseerr.example.com", Hostname: "jellyseerr.example.com", Name: "jellyseerr", Pinned: true},
+	}
+
+	// A pinned service wins over partial matches...
+	candidates := RankCandidates(ParseQuery("jel"), services)
+	if len(candidates) != 2 || candidates[0].Service.ID != "jellyseerr.example.com" {
+		t.Fatalf("Expected pinned service first, got %+v", candidates)
+	}
+	if candidates[0].BoostScore != ScorePinnedBonus {
+		t.Errorf("Expected pinned bonus in boost score, got %.2f", candidates[0].BoostScore)
+	}
+
+	// ...but never over an exact name match
+	candidates = RankCandidates(ParseQuery("jellyfin"), services)
+	if len(candidates) == 0 || candidates[0].Service.ID != "jellyfin.example.com" {
+		t.Errorf("Expected exact match first, got %+v", candidates)
+	}
+}
+
+func TestRankCandidatesFor_PersonalUsage(t *testing.T) {
+	services := []*Service{
+		{ID: "jellyfin.example.com", Hostname: "jellyfin.example.com", Name: "jellyfin", Frecency: 500},
+		{ID: "jellyseerr.example.com", Hostname: "jellyseerr.example.com", Name: "jellyseerr", Frecency: 2},
+	}
+	query := ParseQuery("jelly")
+
+	// Globally, the household favorite wins
+	global := RankCandidatesFor(query, services, nil)
+	if global[0].Service.ID != "jellyfin.example.com" {
+		t.Fatalf("Expected jellyfin first without profile, got %s", global[0].Service.ID)
+	}
+	if global[0].PersonalScore != 0 {
+		t.Errorf("Expected no personal score without profile, got %.2f", global[0].PersonalScore)
+	}
+
+	// A client who only uses jellyseerr gets it first
+	profile := &UsageProfile{
+		Personal:       map[string]float64{"jellyseerr.example.com": 200},
+		PersonalWeight: 0.7,
+		GlobalWeight:   0.3,
+	}
+	personal := RankCandidatesFor(query, services, profile)
+	if personal[0].Service.ID != "jellyseerr.example.com" {
+		t.Fatalf("Expected jellyseerr first with profile, got %s", personal[0].Service.ID)
+	}
+	if personal[0].PersonalScore <= 0 || personal[0].UsageScore < personal[0].PersonalScore {
+		t.Errorf("Expected personal score to be part of usage score, got %+v", personal[0])
+	}
+
+	// RankCandidates keeps the global behavior
+	if RankCandidates(query, services)[0].Service.ID != "jellyfin.example.com" {
+		t.Error("Expected RankCandidates to ignore personal usage")
+	}
+}
+
+func TestRankCandidatesFor_Learned(t *testing.T) {
+	services := []*Service{
+		{ID: "jackett.example.com", Hostname: "jackett.example.com", Name: "jackett", Frecency: 20},
+		{ID: "jellyfin.example.com", Hostname: "jellyfin.example.com", Name: "jellyfin"},
+	}
+	query := ParseQuery("j")
+
+	// Without history, the more used service wins
+	if got := RankCandidates(query, services)[0].Service.ID; got != "jackett.example.com" {
+		t.Fatalf("Expected jackett first without history, got %s", got)
+	}
+
+	// "j" always led to jellyfin
+	profile := &UsageProfile{
+		Learned: map[string]float64{"jellyfin.example.com": 10},
+	}
+	candidates := RankCandidatesFor(query, services, profile)
+	if candidates[0].Service.ID != "jellyfin.example.com" {
+		t.Fatalf("Expected jellyfin first with history, got %s", candidates[0].Service.ID)
+	}
+	if candidates[0].LearnedScore <= 0 {
+		t.Errorf("Expected a learned score, got %+v", candidates[0])
+	}
+
+	// Without personal usage, the global usage is not reweighted
+	if candidates[1].UsageScore != usageScore(20) {
+		t.Errorf("Expected global usage score to be kept, got %.2f", candidates[1].UsageScore)
+	}
+}
+
+func TestRankCandidatesFor_Demoted(t *testing.T) {
+	services := []*Service{
+		{ID: "jackett.example.com", Hostname: "jackett.example.com", Name: "jackett", Frecency: 20},
+		{ID: "jellyfin.example.com", Hostname: "jellyfin.example.com", Name: "jellyfin"},
+	}
+
+	// "j" led to jackett several times, but the user reported it wrong once
+	profile := &UsageProfile{
+		Learned: map[string]float64{"jackett.example.com": 5},
+		Demoted: map[string]float64{"jackett.example.com": 1},
+	}
+	candidates := RankCandidatesFor(ParseQuery("j"), services, profile)
+	if candidates[0].Service.ID != "jellyfin.example.com" {
+		t.Fatalf("Expected jellyfin first after demotion, got %s", candidates[0].Service.ID)
+	}
+	if candidates[1].LearnedScore >= 0 {
+		t.Errorf("Expected a negative learned score for the demoted service, got %.2f", candidates[1].LearnedScore)
+	}
+
+	// Demotion doesn't override an exact match
+	candidates = RankCandidatesFor(ParseQuery("jackett"), services, profile)
+	if candidates[0].Service.ID != "jackett.example.com" {
+		t.Errorf("Expected exact match to survive demotion, got %s", candidates[0].Service.ID)
+	}
+}
+
+func TestRankCandidates_SharedHost(t *testing.T) {
+	services := []*Service{
+		{ID: "domain.ext/grafana", Hostname: "domain.ext", URL: "https://domain.ext/grafana", Name: "grafana"},
+		{ID: "domain.ext/prometheus", Hostname: "domain.ext", URL: "https://domain.ext/prometheus", Name: "prometheus"},
+		{ID: "nas.lan:5001", Hostname: "nas.lan", URL: "https://nas.lan:5001", Name: "nas"},
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"grafana", "domain.ext/grafana"},
+		{"prom", "domain.ext/prometheus"},
+		{"nas", "nas.lan:5001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			candidates := RankCandidates(ParseQuery(tt.query), services)
+			if len(candidates) == 0 {
+				t.Fatalf("No candidates for %q", tt.query)
+			}
+			if candidates[0].Service.ID != tt.want {
+				t.Errorf("Expected %s first for %q, got %s", tt.want, tt.query, candidates[0].Service.ID)
+			}
+		})
+	}
+}
+
+func TestRankCandidates_Metadata(t *testing.T) {
+	services := []*Service{
+		{ID: "ha.domain.ext", Hostname: "ha.domain.ext", Name: "ha", DisplayName: "Home Assistant", Group: "Home"},
+		{ID: "jellyfin.domain.ext", Hostname: "jellyfin.domain.ext", Name: "jellyfin", DisplayName: "Jellyfin", Group: "Media",
+			Description: "Movies and TV shows"},
+		{ID: "adguard.domain.ext", Hostname: "adguard.domain.ext", Name: "adguard", DisplayName: "AdGuard Home", Group: "Infrastructure",
+			Description: "Network-wide ads blocking"},
+		{ID: "mediamtx.domain.ext", Hostname: "mediamtx.domain.ext", Name: "mediamtx", DisplayName: "MediaMTX", Group: "Cameras"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"display name", "home assistant", "ha.domain.ext"},
+		{"quoted display name", `"home assistant"`, "ha.domain.ext"},
+		{"display name acronym", "ah", "adguard.domain.ext"},
+		{"description word", "ads", "adguard.domain.ext"},
+		{"description prefix", "movie", "jellyfin.domain.ext"},
+		{"group", "infra", "adguard.domain.ext"},
+		{"hostname beats group", "media", "mediamtx.domain.ext"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := RankCandidates(ParseQuery(tt.query), services)
+			if len(candidates) == 0 {
+				t.Fatalf("No candidates for %q", tt.query)
+			}
+			if candidates[0].Service.ID != tt.want {
+				t.Errorf("Expected %s first for %q, got %s", tt.want, tt.query, candidates[0].Service.ID)
+			}
+		})
+	}
+
+	// Group members are found even when no hostname matches
+	candidates := RankCandidates(ParseQuery("media"), services)
+	found := false
+	for _, c := range candidates {
+		if c.Service.ID == "jellyfin.domain.ext" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected jellyfin to match its group name")
+	}
+}
+
+func TestRankCandidates_Group(t *testing.T) {
+	services := []*Service{
+		{ID: "grafana.mon.domain.ext", Hostname: "grafana.mon.domain.ext", Name: "grafana", Group: "Monitoring", Frecency: 50},
+		{ID: "grafana.dev.domain.ext", Hostname: "grafana.dev.domain.ext", Name: "grafana", Group: "Dev"},
+		{ID: "prometheus.domain.ext", Hostname: "prometheus.domain.ext", Name: "prometheus", Group: "Monitoring"},
+		{ID: "jellyfin.domain.ext", Hostname: "jellyfin.domain.ext", Name: "jellyfin", Group: "Media"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"exact group", "dev:grafana", []string{"grafana.dev.domain.ext"}},
+		{"other group", "monitoring:grafana", []string{"grafana.mon.domain.ext"}},
+		{"partial group", "mon:graf", []string{"grafana.mon.domain.ext"}},
+		{"misspelled group", "monitorign:grafana", []string{"grafana.mon.domain.ext"}},
+		{"bare group", "monitoring:", []string{"grafana.mon.domain.ext", "prometheus.domain.ext"}},
+		{"nothing in group", "media:grafana", nil},
+		{"unknown group", "xyz:grafana", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := RankCandidates(ParseQuery(tt.query), services)
+
+			got := make([]string, 0, len(candidates))
+			for _, c := range candidates {
+				got = append(got, c.Service.ID)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && !slicesEqual(got, tt.want)) {
+				t.Errorf("RankCandidates(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
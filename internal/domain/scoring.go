@@ -21,16 +21,45 @@ const (
 	// Exact hostname match bonus (huge boost)
 	ScoreExactHostnameBonus = 200.0
 
+	// Homepage metadata weights (relative to a hostname match of the same quality)
+	ScoreDisplayNameWeight = 0.8
+	ScoreGroupWeight       = 0.5
+	ScoreDescriptionWeight = 0.3
+
 	// Usage weight (usage counter contributes to final score)
 	ScoreUsageWeight = 0.1
+
+	// Learned weight (past resolutions of the same query contribute to final score)
+	ScoreLearnedWeight = 0.2
+
+	// Demoted weight (resolutions reported as wrong for the same query are penalized)
+	ScoreDemotedWeight = 1.0
+
+	// Pinned bonus: a pinned service wins over partial matches of other services,
+	// never over an exact name match
+	ScorePinnedBonus = 50.0
 )
 
+// UsageProfile holds what is known about the request beyond the query text:
+// the usage of the requesting client (blended with the global usage counters)
+// and the services the same query resolved to in the past
+type UsageProfile struct {
+	Personal       map[string]float64 // service ID -> personal frecency, nil when personalization is disabled
+	PersonalWeight float64
+	GlobalWeight   float64
+	Learned        map[string]float64 // service ID -> number of times this query led to the service
+	Demoted        map[string]float64 // service ID -> number of times this resolution was reported wrong
+}
+
 // Candidate represents a service candidate with its match score
 type Candidate struct {
-	Service      *Service
-	LexicalScore float64 // Score from fuzzy matching
-	UsageScore   float64 // Score from usage learning
-	TotalScore   float64 // Combined score
+	Service       *Service
+	LexicalScore  float64 // Score from fuzzy matching
+	UsageScore    float64 // Score from usage learning (global and personal blended)
+	PersonalScore float64 // Part of UsageScore coming from the client's own usage
+	LearnedScore  float64 // Score from past resolutions of the same query (negative when reported wrong)
+	BoostScore    float64 // Static boost from the overlay, pinned bonus included
+	TotalScore    float64 // Combined score
 }
 
 // Score calculates the match score for a service against a query
@@ -52,6 +81,98 @@ func Score(query *Query, service *Service) float64 {
 		totalScore = scoreWithSubdomains(query, hostFragments)
 	}
 
+	// Aliases and Homepage metadata only compete with the top-level name
+	if !query.HasDot {
+		// Services published under a path are named after it ("domain.ext/grafana")
+		if name := normalizeFragment(service.Name); name != "" && len(hostFragments) > 0 && name != normalizeFragment(hostFragments[0]) {
+			if nameScore := scoreTopLevelOnly(query.Fragments, []string{name}); nameScore > totalScore {
+				totalScore = nameScore
+			}
+		}
+		if aliasScore := scoreAliases(query.Fragments, service.Aliases); aliasScore > totalScore {
+			totalScore = aliasScore
+		}
+		if metadataScore := scoreMetadata(query.Fragments, service); metadataScore > totalScore {
+			totalScore = metadataScore
+		}
+	}
+
+	return totalScore
+}
+
+// scoreAliases scores the query against the service aliases (best alias wins).
+// A query equal to an alias scores as an exact hostname match.
+func scoreAliases(queryFragments []string, aliases []string) float64 {
+	if len(queryFragments) == 0 {
+		return 0.0
+	}
+
+	joined := normalizeFragment(strings.Join(queryFragments, ""))
+
+	var best float64
+	for _, alias := range aliases {
+		alias = normalizeFragment(alias)
+		if alias == "" {
+			continue
+		}
+
+		if joined == alias {
+			return ScoreExactMatch + ScoreExactHostnameBonus
+		}
+
+		if score := scoreTopLevelOnly(queryFragments, []string{alias}); score > best {
+			best = score
+		}
+	}
+
+	return best
+}
+
+// scoreMetadata scores the query against the Homepage display name, group and description.
+// Each field is weighted below hostname matches; the best field wins.
+func scoreMetadata(queryFragments []string, service *Service) float64 {
+	if len(queryFragments) == 0 {
+		return 0.0
+	}
+
+	displayName := scoreWords(queryFragments, splitWords(service.DisplayName), 0)
+	acronym := acronymScore(normalizeFragment(strings.Join(queryFragments, "")), splitWords(service.DisplayName))
+	best := math.Max(displayName, acronym) * ScoreDisplayNameWeight
+
+	// Groups and descriptions are matched on whole words or prefixes only
+	if score := scoreWords(queryFragments, splitWords(service.Group), ScorePrefixMatch) * ScoreGroupWeight; score > best {
+		best = score
+	}
+	if score := scoreWords(queryFragments, splitWords(service.Description), ScorePrefixMatch) * ScoreDescriptionWeight; score > best {
+		best = score
+	}
+
+	return best
+}
+
+// scoreWords sums, for each query fragment, its best score against the words of a field.
+// Word scores under minScore are ignored.
+func scoreWords(queryFragments []string, words []string, minScore float64) float64 {
+	if len(words) == 0 {
+		return 0.0
+	}
+
+	var totalScore float64
+	for _, qFrag := range queryFragments {
+		// Single letters would match half of any free text
+		if len([]rune(normalizeFragment(qFrag))) < 2 {
+			continue
+		}
+
+		bestScore := 0.0
+		for _, word := range words {
+			if score := scoreFragment(qFrag, word, 0); score >= minScore && score > bestScore {
+				bestScore = score
+			}
+		}
+		totalScore += bestScore
+	}
+
 	return totalScore
 }
 
@@ -141,6 +262,7 @@ func scoreWithSubdomains(query *Query, hostFragments []string) float64 {
 
 // scoreFragment scores a single query fragment against a hostname fragment
 func scoreFragment(queryFrag, hostFrag string, position int) float64 {
+	rawHostFrag := hostFrag
 	queryFrag = normalizeFragment(queryFrag)
 	hostFrag = normalizeFragment(hostFrag)
 
@@ -166,13 +288,8 @@ func scoreFragment(queryFrag, hostFrag string, position int) float64 {
 		return ScoreSubstringMatch + substringBonus
 	}
 
-	// Fuzzy match (Levenshtein-like)
-	similarity := calculateSimilarity(queryFrag, hostFrag)
-	if similarity > 0.5 {
-		return ScoreFuzzyMatch * similarity
-	}
-
-	return 0.0
+	// Fuzzy match (acronym, subsequence or typo)
+	return fuzzyScore(queryFrag, rawHostFrag)
 }
 
 // calculatePositionBonus gives bonus for earlier positions
@@ -180,25 +297,22 @@ func calculatePositionBonus(position int) float64 {
 	return ScorePositionBonus * math.Exp(-float64(position)*0.3)
 }
 
-// calculateSimilarity calculates fuzzy similarity between two strings
-func calculateSimilarity(s1, s2 string) float64 {
-	if s1 == "" || s2 == "" {
-		return 0.0
-	}
+// RankCandidates ranks service candidates by combining lexical and global usage scores
+func RankCandidates(query *Query, services []*Service) []*Candidate {
+	return RankCandidatesFor(query, services, nil)
+}
 
-	// Simple similarity: ratio of matching characters
-	matches := 0
-	for _, c := range s1 {
-		if strings.ContainsRune(s2, c) {
-			matches++
-		}
+// RankCandidatesFor ranks service candidates by combining lexical and usage scores.
+// When a usage profile is given, the client's personal usage is blended with the global usage
+// and services the query already led to get a learned bonus (or a penalty when reported wrong).
+func RankCandidatesFor(query *Query, services []*Service, profile *UsageProfile) []*Candidate {
+	// Limit the search to a group ("media:jel"), a bare scope ("media:") lists the whole group
+	groupOnly := false
+	if query != nil && query.Group != "" {
+		services = filterGroup(query.Group, services)
+		groupOnly = len(query.Fragments) == 0
 	}
 
-	return float64(matches) / float64(len(s1))
-}
-
-// RankCandidates ranks service candidates by combining lexical and usage scores
-func RankCandidates(query *Query, services []*Service) []*Candidate {
 	candidates := make([]*Candidate, 0, len(services))
 
 	for _, service := range services {
@@ -209,24 +323,41 @@ func RankCandidates(query *Query, services []*Service) []*Candidate {
 
 		lexicalScore := Score(query, service)
 
+		if groupOnly {
+			lexicalScore = ScoreExactMatch * ScoreGroupWeight
+		}
+
 		// Skip services with zero lexical score (no match)
 		if lexicalScore == 0.0 {
 			continue
 		}
 
-		// Calculate usage score (logarithmic to prevent dominance)
-		usageScore := 0.0
-		if service.Counter > 0 {
-			usageScore = math.Log10(float64(service.Counter)+1) * ScoreUsageWeight * 100
+		usage := usageScore(service.Frecency)
+		personal := 0.0
+		learned := 0.0
+		if profile != nil {
+			if profile.Personal != nil {
+				personal = usageScore(profile.Personal[service.ID]) * profile.PersonalWeight
+				usage = usage*profile.GlobalWeight + personal
+			}
+			learned = learnedScore(profile.Learned[service.ID]) - demotedScore(profile.Demoted[service.ID])
+		}
+
+		boost := service.Boost
+		if service.Pinned {
+			boost += ScorePinnedBonus
 		}
 
-		totalScore := lexicalScore + usageScore
+		totalScore := lexicalScore + usage + learned + boost
 
 		candidates = append(candidates, &Candidate{
-			Service:      service,
-			LexicalScore: lexicalScore,
-			UsageScore:   usageScore,
-			TotalScore:   totalScore,
+			Service:       service,
+			LexicalScore:  lexicalScore,
+			UsageScore:    usage,
+			PersonalScore: personal,
+			LearnedScore:  learned,
+			BoostScore:    boost,
+			TotalScore:    totalScore,
 		})
 	}
 
@@ -236,6 +367,60 @@ func RankCandidates(query *Query, services []*Service) []*Candidate {
 	return candidates
 }
 
+// filterGroup keeps the services of the group that best matches the query scope.
+// Group names are matched like hostname fragments, so partial or misspelled names still find their group.
+func filterGroup(scope string, services []*Service) []*Service {
+	groupScores := make(map[string]float64)
+	best := 0.0
+	for _, service := range services {
+		if _, seen := groupScores[service.Group]; seen || service.Group == "" {
+			continue
+		}
+		score := scoreFragment(scope, service.Group, 0)
+		groupScores[service.Group] = score
+		if score > best {
+			best = score
+		}
+	}
+
+	// No group looks like the scope
+	if best == 0.0 {
+		return nil
+	}
+
+	filtered := make([]*Service, 0, len(services))
+	for _, service := range services {
+		if service.Group != "" && groupScores[service.Group] == best {
+			filtered = append(filtered, service)
+		}
+	}
+	return filtered
+}
+
+// usageScore converts a (decayed) usage counter into a score (logarithmic to prevent dominance)
+func usageScore(frecency float64) float64 {
+	if frecency <= 0 {
+		return 0.0
+	}
+	return math.Log10(frecency+1) * ScoreUsageWeight * 100
+}
+
+// learnedScore converts the number of past resolutions of a query into a score (logarithmic)
+func learnedScore(count float64) float64 {
+	if count <= 0 {
+		return 0.0
+	}
+	return math.Log10(count+1) * ScoreLearnedWeight * 100
+}
+
+// demotedScore converts the number of wrong reports for a query into a penalty (logarithmic)
+func demotedScore(count float64) float64 {
+	if count <= 0 {
+		return 0.0
+	}
+	return math.Log10(count+1) * ScoreDemotedWeight * 100
+}
+
 // sortCandidates sorts candidates by total score (descending)
 func sortCandidates(candidates []*Candidate) {
 	// Simple bubble sort (fine for small lists)
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// SearchPlaceholder is replaced by the escaped query in search engine templates
+	SearchPlaceholder = "{q}"
+
+	// BangPrefix starts a query forwarded to a named search engine ("!gh jump")
+	BangPrefix = "!"
+
+	// FeedbackBang is the reserved bang reporting the last redirect as wrong ("!wrong")
+	FeedbackBang = "wrong"
+)
+
+// ErrInvalidSearchTemplate is returned when a search engine template cannot be used
+var ErrInvalidSearchTemplate = errors.New("invalid search engine template")
+
+// SearchURL fills a search engine template with the escaped query
+func SearchURL(template, query string) string {
+	return strings.ReplaceAll(template, SearchPlaceholder, url.QueryEscape(query))
+}
+
+// ValidateSearchTemplate checks that a template is an absolute http(s) URL containing {q}
+func ValidateSearchTemplate(template string) error {
+	if !strings.Contains(template, SearchPlaceholder) {
+		return fmt.Errorf("%w: %q has no %s placeholder", ErrInvalidSearchTemplate, template, SearchPlaceholder)
+	}
+
+	u, err := url.Parse(SearchURL(template, "jump"))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSearchTemplate, err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("%w: %q must be an absolute http(s) URL", ErrInvalidSearchTemplate, template)
+	}
+
+	return nil
+}
+
+// ParseBang splits a bang query ("!gh jump") into the engine name and the search text
+func ParseBang(query string) (name, text string, ok bool) {
+	if !strings.HasPrefix(query, BangPrefix) {
+		return "", "", false
+	}
+
+	name, text, _ = strings.Cut(strings.TrimPrefix(query, BangPrefix), " ")
+	name = strings.ToLower(name)
+	if name == "" {
+		return "", "", false
+	}
+
+	return name, strings.TrimSpace(text), true
+}
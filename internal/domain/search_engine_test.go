@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSearchURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		query    string
+		want     string
+	}{
+		{"simple", "https://duckduckgo.com/?q={q}", "jump", "https://duckduckgo.com/?q=jump"},
+		{"spaces", "https://www.google.com/search?q={q}", "go redis", "https://www.google.com/search?q=go+redis"},
+		{"special characters", "https://searx.example.com/search?q={q}&lang=en", "a&b=c#d", "https://searx.example.com/search?q=a%26b%3Dc%23d&lang=en"},
+		{"empty query", "https://github.com/search?q={q}", "", "https://github.com/search?q="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SearchURL(tt.template, tt.query); got != tt.want {
+				t.Errorf("SearchURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSearchTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{"valid https", "https://duckduckgo.com/?q={q}", false},
+		{"valid http", "http://searx.lan/search?q={q}", false},
+		{"missing placeholder", "https://duckduckgo.com/", true},
+		{"relative", "/search?q={q}", true},
+		{"javascript scheme", "javascript:alert({q})", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSearchTemplate(tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSearchTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSearchTemplate) {
+				t.Errorf("expected ErrInvalidSearchTemplate, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseBang(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantName string
+		wantText string
+		wantOK   bool
+	}{
+		{"!g foo", "g", "foo", true},
+		{"!GH jump blueprint", "gh", "jump blueprint", true},
+		{"!ddg", "ddg", "", true},
+		{"!", "", "", false},
+		{"! foo", "", "", false},
+		{"jellyfin", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			name, text, ok := ParseBang(tt.query)
+			if name != tt.wantName || text != tt.wantText || ok != tt.wantOK {
+				t.Errorf("ParseBang(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.query, name, text, ok, tt.wantName, tt.wantText, tt.wantOK)
+			}
+		})
+	}
+}
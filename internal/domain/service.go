@@ -1,41 +1,97 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Sources a service can be discovered from
+const (
+	SourceHomepage = "homepage" // Homepage services.yaml
+	SourceAPI      = "api"      // /api/v1/services, left alone by source reloads
+)
+
+// ErrInvalidServiceURL is returned when a service URL cannot be redirected to
+var ErrInvalidServiceURL = errors.New("invalid service URL")
 
 // Service represents the canonical runtime truth of a routable service.
 //
 // It is NOT tied to Homepage, Redis or any external source.
 // All inputs (files, cache, learning) are merged into this structure.
 //
-// A Service is considered uniquely identified by its Hostname.
+// A Service is uniquely identified by its ID: several services may share a Hostname.
 type Service struct {
 	// ─────────────────────────────
 	// Identity (immutable)
 	// ─────────────────────────────
 
-	// ID is the canonical unique identifier.
-	// It MUST be equal to Hostname.
+	// ID is the canonical unique identifier, derived from the target URL
+	// (see ServiceID). It equals Hostname for plain https://<hostname> services.
 	ID string
 
 	// Hostname is the DNS hostname of the service.
 	// Example: jellyfin.domain.ext
 	Hostname string
 
+	// URL is the full target URL from the source, with scheme, port and path.
+	// Example: https://nas.lan:5001 (empty = https://<Hostname>)
+	URL string
+
 	// ─────────────────────────────
 	// Functional description
 	// (may be overwritten by homepage reload)
 	// ─────────────────────────────
 
-	// Name is derived from the first DNS label.
+	// Name is derived from the first DNS label (or the last path segment
+	// for services published under a path).
 	// Example: jellyfin
 	Name string
 
+	// DisplayName is the name the service is listed under in Homepage.
+	// Example: AdGuard Home
+	DisplayName string
+
+	// Description is the free-text description from Homepage.
+	Description string
+
+	// Group is the Homepage group the service belongs to.
+	// Example: Media
+	Group string
+
+	// ─────────────────────────────
+	// Jump overlay
+	// (applied after each homepage reload)
+	// ─────────────────────────────
+
+	// Aliases are extra keywords matched as if they were the service name.
+	// Example: dns, adblock for adguard.domain.ext
+	Aliases []string
+
+	// Boost is a static score added to the ranking of the service.
+	Boost float64
+
+	// Hidden services are not offered in suggestions or the chooser,
+	// but can still be reached by searching.
+	Hidden bool
+
+	// Pinned services get ScorePinnedBonus whenever they match a query.
+	Pinned bool
+
+	// TLSTimeout overrides the global TLS validation timeout (0 = default).
+	TLSTimeout time.Duration
+
+	// RedirectURL replaces https://<Hostname> as redirect target (empty = default).
+	RedirectURL string
+
 	// ─────────────────────────────
 	// Provenance & observation
 	// ─────────────────────────────
 
 	// Sources indicates where this service was discovered from.
-	// Example: homepage, redis
+	// Example: homepage, api
 	Sources []string
 
 	// LastSeenAt is updated whenever the service is observed
@@ -49,6 +105,11 @@ type Service struct {
 	// Counter represents the number of successful redirects.
 	Counter int64
 
+	// Frecency is the usage counter with exponential time decay:
+	// +1 on each redirect, halved every configured half-life.
+	// It drives the usage part of the ranking.
+	Frecency float64
+
 	// CreatedAt is the first time the service was discovered.
 	CreatedAt time.Time
 
@@ -66,3 +127,92 @@ type Service struct {
 	// It may be garbage-collected later.
 	Disabled bool
 }
+
+// NewService builds a service from its target URL: ID, hostname, URL and name.
+// Only http(s) URLs with a hostname are accepted; the fragment is dropped.
+func NewService(rawURL string) (*Service, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidServiceURL, err)
+	}
+
+	// Only web targets can be redirected to
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("%w: scheme %q is not http(s)", ErrInvalidServiceURL, u.Scheme)
+	}
+
+	hostname := strings.ToLower(u.Hostname())
+	if hostname == "" {
+		return nil, fmt.Errorf("%w: no hostname in %q", ErrInvalidServiceURL, rawURL)
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+
+	// Services published under a path are named after it, others after their first DNS label
+	name := strings.Split(hostname, ".")[0]
+	if segment := lastPathSegment(u.Path); segment != "" {
+		name = segment
+	}
+
+	return &Service{
+		ID:       ServiceID(u),
+		Hostname: hostname,
+		URL:      u.String(),
+		Name:     name,
+	}, nil
+}
+
+// lastPathSegment returns the last non-empty segment of a URL path
+// Example: "/apps/grafana/" -> "grafana"
+func lastPathSegment(path string) string {
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return ""
+	}
+	return strings.ToLower(segments[len(segments)-1])
+}
+
+// HasSource reports whether the service was discovered from a source
+func (s *Service) HasSource(source string) bool {
+	for _, src := range s.Sources {
+		if src == source {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidationTimeout returns the TLS timeout for this service, falling back to def
+func (s *Service) ValidationTimeout(def time.Duration) time.Duration {
+	if s.TLSTimeout > 0 {
+		return s.TLSTimeout
+	}
+	return def
+}
+
+// BaseURL returns the URL the service is reached at (https://<Hostname> when the source gave none)
+func (s *Service) BaseURL() string {
+	if s.URL != "" {
+		return s.URL
+	}
+	return (&url.URL{Scheme: "https", Host: s.Hostname}).String()
+}
+
+// TargetURL returns the redirect URL of the service for a deep-link path.
+// A custom RedirectURL is used as is when there is no path; deep links always
+// target the service base URL.
+func (s *Service) TargetURL(path string) (string, error) {
+	if s.RedirectURL != "" && path == "" {
+		return s.RedirectURL, nil
+	}
+	if s.URL == "" {
+		return BuildTargetURL(s.Hostname, path)
+	}
+
+	base, err := url.Parse(s.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid service URL %q: %w", s.URL, err)
+	}
+	return JoinTargetURL(base, path)
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// ShortcutPrefix starts a query targeting Jump's own endpoints ("/inf")
+const ShortcutPrefix = "/"
+
+// Shortcut describes an internal endpoint reachable with a "/" query
+type Shortcut struct {
+	// Path is the endpoint path.
+	// Example: /infra
+	Path string
+
+	// Name is the name the shortcut is matched against.
+	// Example: infra
+	Name string
+
+	// Description tells what the endpoint is for.
+	// Example: Infrastructure status
+	Description string
+
+	// Safe marks endpoints that can be opened from the address bar:
+	// GET requests without side effects. Other shortcuts are listed only.
+	Safe bool
+}
+
+// ShortcutCandidate represents a shortcut candidate with its match score
+type ShortcutCandidate struct {
+	Shortcut Shortcut
+	Score    float64
+}
+
+// shortcutFragments splits a "/" query into the fragments matched against shortcuts
+// ("/ready" -> ["ready"], "/opensearch.xml" -> ["opensearch", "xml"])
+func shortcutFragments(query string) []string {
+	query = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(query), ShortcutPrefix))
+	return strings.FieldsFunc(query, func(r rune) bool {
+		return strings.ContainsRune(" /._-", r)
+	})
+}
+
+// ScoreShortcut calculates the match score for a shortcut against a "/" query.
+// The name is scored like a service name, the description like a service description.
+func ScoreShortcut(query string, shortcut Shortcut) float64 {
+	fragments := shortcutFragments(query)
+	if len(fragments) == 0 {
+		return 0.0
+	}
+
+	best := scoreTopLevelOnly(fragments, []string{strings.ToLower(shortcut.Name)})
+	if score := scoreWords(fragments, splitWords(shortcut.Description), ScorePrefixMatch) * ScoreDescriptionWeight; score > best {
+		best = score
+	}
+
+	return best
+}
+
+// RankShortcuts ranks the safe shortcuts matching a "/" query, best first.
+// Shortcuts with an equal score are ordered by path.
+func RankShortcuts(query string, shortcuts []Shortcut) []*ShortcutCandidate {
+	var candidates []*ShortcutCandidate
+	for _, shortcut := range shortcuts {
+		if !shortcut.Safe {
+			continue
+		}
+		if score := ScoreShortcut(query, shortcut); score > 0 {
+			candidates = append(candidates, &ShortcutCandidate{Shortcut: shortcut, Score: score})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Shortcut.Path < candidates[j].Shortcut.Path
+	})
+
+	return candidates
+}
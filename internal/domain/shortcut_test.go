@@ -0,0 +1,54 @@
+package domain
+
+import "testing"
+
+var testShortcuts = []Shortcut{
+	{Path: "/infra", Name: "infra", Description: "Infrastructure status", Safe: true},
+	{Path: "/healthz", Name: "healthz", Description: "Health check", Safe: true},
+	{Path: "/readyz", Name: "readyz", Description: "Readiness probe", Safe: true},
+	{Path: "/reload", Name: "reload", Description: "Reload services and bookmarks", Safe: false},
+	{Path: "/opensearch.xml", Name: "opensearch", Description: "OpenSearch description", Safe: true},
+}
+
+func TestRankShortcuts(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string // best path, empty when nothing matches
+	}{
+		{"prefix", "/inf", "/infra"},
+		{"exact name", "/healthz", "/healthz"},
+		{"typo", "/raedyz", "/readyz"},
+		{"description word", "/health", "/healthz"},
+		{"path with extension", "/opensearch.xml", "/opensearch.xml"},
+		{"unsafe shortcut never matches", "/reload", ""},
+		{"no match", "/zzz", ""},
+		{"empty", "/", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := RankShortcuts(tt.query, testShortcuts)
+
+			got := ""
+			if len(candidates) > 0 {
+				got = candidates[0].Shortcut.Path
+			}
+			if got != tt.want {
+				t.Errorf("RankShortcuts(%q) best = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankShortcuts_Order(t *testing.T) {
+	candidates := RankShortcuts("/r", testShortcuts)
+
+	for i := 1; i < len(candidates); i++ {
+		prev, cur := candidates[i-1], candidates[i]
+		if prev.Score < cur.Score || (prev.Score == cur.Score && prev.Shortcut.Path > cur.Shortcut.Path) {
+			t.Errorf("candidates out of order: %s (%.2f) before %s (%.2f)",
+				prev.Shortcut.Path, prev.Score, cur.Shortcut.Path, cur.Score)
+		}
+	}
+}
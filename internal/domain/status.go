@@ -9,13 +9,40 @@ import (
 	"time"
 )
 
+// CandidateValidator checks whether a candidate can be redirected to.
+// It must honor ctx cancellation so losing validations stop early.
+type CandidateValidator func(ctx context.Context, candidate *Candidate) error
+
+// TLSValidator returns a CandidateValidator checking the service base URL
+// (with the service's own timeout when it overrides the default)
+func TLSValidator(timeout time.Duration) CandidateValidator {
+	return func(ctx context.Context, candidate *Candidate) error {
+		return ValidateServiceContext(ctx, candidate.Service, candidate.Service.ValidationTimeout(timeout))
+	}
+}
+
+// ValidateServiceContext checks that a service answers at its base URL:
+// scheme, port and path are honored, the certificate is verified for https targets
+func ValidateServiceContext(ctx context.Context, service *Service, timeout time.Duration) error {
+	return ValidateURLContext(ctx, service.BaseURL(), timeout)
+}
+
 // ValidateTLS checks if a service is reachable and has a valid TLS certificate
 func ValidateTLS(hostname string, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+	return ValidateTLSContext(context.Background(), hostname, timeout)
+}
+
+// ValidateTLSContext is ValidateTLS bound to a parent context:
+// the check stops as soon as ctx is cancelled or the timeout elapses
+func ValidateTLSContext(parent context.Context, hostname string, timeout time.Duration) error {
+	return ValidateURLContext(parent, fmt.Sprintf("https://%s", hostname), timeout)
+}
 
-	// Construct HTTPS URL
-	url := fmt.Sprintf("https://%s", hostname)
+// ValidateURLContext checks that a URL answers (with a valid certificate for https URLs).
+// The check stops as soon as ctx is cancelled or the timeout elapses
+func ValidateURLContext(parent context.Context, url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
 
 	// Create HTTP client with custom transport (short timeout)
 	client := &http.Client{
@@ -48,13 +75,13 @@ func ValidateTLS(hostname string, timeout time.Duration) error {
 	// Execute request
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to validate TLS: %w", err)
+		return fmt.Errorf("failed to reach %s: %w", url, err)
 	}
 	defer func() {
 		_ = resp.Body.Close() // Ignore close errors in validation context
 	}()
 
-	// Any response means the service is reachable with valid TLS
+	// Any response means the service is reachable (with valid TLS for https)
 	return nil
 }
 
@@ -63,16 +90,66 @@ func IsServiceHealthy(service *Service, timeout time.Duration) bool {
 	if service == nil {
 		return false
 	}
-	return ValidateTLS(service.Hostname, timeout) == nil
+	return ValidateServiceContext(context.Background(), service, timeout) == nil
 }
 
-// ValidateMultiple validates multiple services and returns healthy ones
-func ValidateMultiple(candidates []*Candidate, timeout time.Duration) *Candidate {
-	for _, candidate := range candidates {
-		if err := ValidateTLS(candidate.Service.Hostname, timeout); err == nil {
-			// First healthy service wins
-			return candidate
+// ValidateMultiple validates candidates concurrently and returns the highest-ranked
+// healthy one (candidates must be sorted by rank), or nil if none is healthy.
+// Remaining validations are cancelled as soon as the winner is known, i.e. once it
+// succeeded and every better-ranked candidate failed.
+// errs[i] holds the failure of candidate i for every candidate ranked above the winner;
+// entries from the winner onwards are nil.
+func ValidateMultiple(ctx context.Context, candidates []*Candidate, validate CandidateValidator) (winner *Candidate, errs []error) {
+	errs = make([]error, len(candidates))
+	if len(candidates) == 0 {
+		return nil, errs
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops validations still running once the winner is known
+
+	results := startValidations(ctx, candidates, validate)
+
+	// Consume results in rank order: a lower-ranked success never beats a pending better rank
+	for i, candidate := range candidates {
+		select {
+		case err := <-results[i]:
+			if err == nil {
+				return candidate, errs
+			}
+			errs[i] = err
+		case <-ctx.Done():
+			for j := i; j < len(candidates); j++ {
+				errs[j] = ctx.Err()
+			}
+			return nil, errs
 		}
 	}
-	return nil
+
+	return nil, errs
+}
+
+// ValidateAll validates every candidate concurrently and waits for all of them.
+// errs[i] is nil when candidate i is healthy.
+func ValidateAll(ctx context.Context, candidates []*Candidate, validate CandidateValidator) []error {
+	results := startValidations(ctx, candidates, validate)
+
+	errs := make([]error, len(candidates))
+	for i := range candidates {
+		errs[i] = <-results[i]
+	}
+	return errs
+}
+
+// startValidations launches one validation per candidate.
+// Channels are buffered so abandoned validations never block.
+func startValidations(ctx context.Context, candidates []*Candidate, validate CandidateValidator) []chan error {
+	results := make([]chan error, len(candidates))
+	for i, candidate := range candidates {
+		results[i] = make(chan error, 1)
+		go func(ch chan<- error, c *Candidate) {
+			ch <- validate(ctx, c)
+		}(results[i], candidate)
+	}
+	return results
 }
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -132,6 +134,23 @@ func TestValidateTLSWithCustomClient(t *testing.T) {
 	}
 }
 
+func TestValidateServiceContext_PlainHTTP(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	service := &Service{Hostname: "127.0.0.1", URL: ts.URL + "/app"}
+	if err := ValidateServiceContext(context.Background(), service, time.Second); err != nil {
+		t.Errorf("ValidateServiceContext() = %v, want nil", err)
+	}
+	if path != "/app" {
+		t.Errorf("ValidateServiceContext() requested %q, want /app", path)
+	}
+}
+
 func TestValidateTLSInvalidURL(t *testing.T) {
 	err := ValidateTLS("not-a-valid-hostname-12345", 1*time.Second)
 	if err == nil {
@@ -145,3 +164,121 @@ func TestValidateTLSEmptyURL(t *testing.T) {
 		t.Error("ValidateTLS() with empty hostname should return error")
 	}
 }
+
+// fakeValidator returns a validator answering after a per-hostname delay
+func fakeValidator(delays map[string]time.Duration, healthy map[string]bool) CandidateValidator {
+	return func(ctx context.Context, candidate *Candidate) error {
+		select {
+		case <-time.After(delays[candidate.Service.Hostname]):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if !healthy[candidate.Service.Hostname] {
+			return errors.New("unhealthy")
+		}
+		return nil
+	}
+}
+
+func rankedCandidates(hostnames ...string) []*Candidate {
+	candidates := make([]*Candidate, len(hostnames))
+	for i, hostname := range hostnames {
+		candidates[i] = &Candidate{Service: &Service{ID: hostname, Hostname: hostname}}
+	}
+	return candidates
+}
+
+func TestValidateMultiple_PreservesRank(t *testing.T) {
+	candidates := rankedCandidates("first", "second", "third")
+
+	// "third" answers first, but "second" is better ranked and healthy
+	validate := fakeValidator(
+		map[string]time.Duration{"first": 30 * time.Millisecond, "second": 20 * time.Millisecond, "third": time.Millisecond},
+		map[string]bool{"first": false, "second": true, "third": true},
+	)
+
+	winner, errs := ValidateMultiple(context.Background(), candidates, validate)
+	if winner == nil || winner.Service.Hostname != "second" {
+		t.Fatalf("ValidateMultiple() = %v, want second", winner)
+	}
+	if errs[0] == nil {
+		t.Error("expected an error for the unhealthy first candidate")
+	}
+	if errs[1] != nil || errs[2] != nil {
+		t.Errorf("expected nil errors from the winner onwards, got %v", errs)
+	}
+}
+
+func TestValidateMultiple_RunsConcurrently(t *testing.T) {
+	candidates := rankedCandidates("a", "b", "c")
+	delay := 50 * time.Millisecond
+
+	validate := fakeValidator(
+		map[string]time.Duration{"a": delay, "b": delay, "c": delay},
+		map[string]bool{"c": true},
+	)
+
+	start := time.Now()
+	winner, _ := ValidateMultiple(context.Background(), candidates, validate)
+	elapsed := time.Since(start)
+
+	if winner == nil || winner.Service.Hostname != "c" {
+		t.Fatalf("ValidateMultiple() = %v, want c", winner)
+	}
+	if elapsed >= 3*delay {
+		t.Errorf("validations look sequential: took %v", elapsed)
+	}
+}
+
+func TestValidateMultiple_CancelsLosers(t *testing.T) {
+	candidates := rankedCandidates("best", "slow")
+
+	cancelled := make(chan struct{})
+	validate := func(ctx context.Context, candidate *Candidate) error {
+		if candidate.Service.Hostname == "best" {
+			return nil
+		}
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	}
+
+	winner, _ := ValidateMultiple(context.Background(), candidates, validate)
+	if winner == nil || winner.Service.Hostname != "best" {
+		t.Fatalf("ValidateMultiple() = %v, want best", winner)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("losing validation was not cancelled")
+	}
+}
+
+func TestValidateMultiple_NoneHealthy(t *testing.T) {
+	candidates := rankedCandidates("a", "b")
+	validate := fakeValidator(nil, nil)
+
+	winner, errs := ValidateMultiple(context.Background(), candidates, validate)
+	if winner != nil {
+		t.Errorf("ValidateMultiple() = %v, want nil", winner)
+	}
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("errs[%d] = nil, want error", i)
+		}
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	candidates := rankedCandidates("up", "down")
+	validate := fakeValidator(nil, map[string]bool{"up": true})
+
+	errs := ValidateAll(context.Background(), candidates, validate)
+	if errs[0] != nil {
+		t.Errorf("errs[0] = %v, want nil", errs[0])
+	}
+	if errs[1] == nil {
+		t.Error("errs[1] = nil, want error")
+	}
+}
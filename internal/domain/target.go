@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrUnsafePath is returned when a deep-link path could escape the target host
+var ErrUnsafePath = errors.New("unsafe deep-link path")
+
+// BuildTargetURL builds the redirect URL for a hostname with an optional
+// deep-link suffix (as parsed into Query.Path).
+// The host always comes from the service: the suffix may only carry a path,
+// a query string and a fragment, so it can never turn into an open redirect.
+func BuildTargetURL(hostname, suffix string) (string, error) {
+	return JoinTargetURL(&url.URL{Scheme: "https", Host: hostname}, suffix)
+}
+
+// JoinTargetURL appends a deep-link suffix to the base URL of a service.
+// The suffix path is appended to the base path ("https://domain.ext/grafana" + "/d/abc"),
+// its query string replaces the base one. Scheme, host and port always come from the base,
+// and dot segments may not leave the base path ("/../other" is rejected under /grafana).
+func JoinTargetURL(base *url.URL, suffix string) (string, error) {
+	target := *base
+
+	if suffix == "" {
+		return target.String(), nil
+	}
+
+	if !strings.ContainsAny(suffix[:1], pathSeparators) {
+		return "", fmt.Errorf("%w: must start with one of %q", ErrUnsafePath, pathSeparators)
+	}
+
+	ref, err := url.Parse(suffix)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnsafePath, err)
+	}
+
+	// "//evil.example" would parse as a host: reject anything carrying authority
+	if ref.Scheme != "" || ref.Host != "" || ref.User != nil || ref.Opaque != "" {
+		return "", fmt.Errorf("%w: %q must not carry a scheme or host", ErrUnsafePath, suffix)
+	}
+
+	joined := strings.TrimSuffix(base.Path, "/") + ref.Path
+	if joined == "" {
+		joined = "/"
+	}
+	target.Path = cleanPath(joined)
+	if !hasPathPrefix(target.Path, base.Path) {
+		return "", fmt.Errorf("%w: %q leaves the service path %q", ErrUnsafePath, suffix, base.Path)
+	}
+
+	// Keep escapes such as %2F, which decoding would turn into path separators
+	target.RawPath = ""
+	if target.Path == joined && (base.RawPath != "" || ref.RawPath != "") {
+		target.RawPath = strings.TrimSuffix(base.EscapedPath(), "/") + ref.EscapedPath()
+	}
+	if ref.RawQuery != "" || strings.Contains(suffix, "?") {
+		target.RawQuery = escapeQuery(ref.RawQuery)
+	}
+	target.Fragment = ref.Fragment
+
+	return target.String(), nil
+}
+
+// cleanPath resolves the dot segments of a path, keeping its trailing slash
+func cleanPath(p string) string {
+	cleaned := path.Clean(p)
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// hasPathPrefix reports whether p is the base path or below it
+func hasPathPrefix(p, base string) bool {
+	base = strings.TrimSuffix(base, "/")
+	return base == "" || p == base || strings.HasPrefix(p, base+"/")
+}
+
+// ServiceID returns the unique ID of a service reachable at a target URL.
+// A plain https://host target keeps the hostname as ID; a port, a path
+// or the http scheme become part of the ID so several services can share a host.
+// Examples: "jellyfin.domain.ext", "nas.lan:5001", "domain.ext/grafana", "http://printer.lan"
+func ServiceID(target *url.URL) string {
+	id := strings.ToLower(target.Host) + strings.TrimSuffix(target.Path, "/")
+	if strings.EqualFold(target.Scheme, "http") {
+		id = "http://" + id
+	}
+	return id
+}
+
+// escapeQuery percent-encodes characters that are not valid in a raw query,
+// keeping existing escapes and the original parameter order
+func escapeQuery(rawQuery string) string {
+	var b strings.Builder
+	for i := 0; i < len(rawQuery); i++ {
+		c := rawQuery[i]
+		if isQueryChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// isQueryChar reports whether c may appear unescaped in a query (RFC 3986)
+func isQueryChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("-._~!$&'()*+,;=:@/?%", c) >= 0
+}
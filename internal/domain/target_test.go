@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestBuildTargetURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostname string
+		suffix   string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "bare hostname",
+			hostname: "grafana.domain.ext",
+			expected: "https://grafana.domain.ext",
+		},
+		{
+			name:     "simple path",
+			hostname: "grafana.domain.ext",
+			suffix:   "/d/node-exporter",
+			expected: "https://grafana.domain.ext/d/node-exporter",
+		},
+		{
+			name:     "path with fragment",
+			hostname: "jellyfin.domain.ext",
+			suffix:   "/web/index.html#!/search",
+			expected: "https://jellyfin.domain.ext/web/index.html#!/search",
+		},
+		{
+			name:     "query string only",
+			hostname: "grafana.domain.ext",
+			suffix:   "?orgId=1&refresh=5s",
+			expected: "https://grafana.domain.ext/?orgId=1&refresh=5s",
+		},
+		{
+			name:     "spaces are escaped",
+			hostname: "wiki.domain.ext",
+			suffix:   "/my page?q=a b",
+			expected: "https://wiki.domain.ext/my%20page?q=a%20b",
+		},
+		{
+			name:     "backslash stays in path",
+			hostname: "wiki.domain.ext",
+			suffix:   "/\\evil.example",
+			expected: "https://wiki.domain.ext/%5Cevil.example",
+		},
+		{
+			name:     "escaped slash is kept",
+			hostname: "gitea.domain.ext",
+			suffix:   "/api/v1/repos/a%2Fb",
+			expected: "https://gitea.domain.ext/api/v1/repos/a%2Fb",
+		},
+		{
+			name:     "protocol-relative suffix is rejected",
+			hostname: "wiki.domain.ext",
+			suffix:   "//evil.example/phish",
+			wantErr:  true,
+		},
+		{
+			name:     "suffix without separator is rejected",
+			hostname: "wiki.domain.ext",
+			suffix:   "evil.example",
+			wantErr:  true,
+		},
+		{
+			name:     "control characters are rejected",
+			hostname: "wiki.domain.ext",
+			suffix:   "/a\nLocation: https://evil.example",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildTargetURL(tt.hostname, tt.suffix)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsafePath) {
+					t.Errorf("BuildTargetURL() error = %v, want ErrUnsafePath", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildTargetURL() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("BuildTargetURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServiceTargetURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		service *Service
+		path    string
+		want    string
+	}{
+		{"default", &Service{Hostname: "grafana.example.com"}, "", "https://grafana.example.com"},
+		{"redirect url", &Service{Hostname: "adguard.example.com", RedirectURL: "https://adguard.example.com/login.html"}, "", "https://adguard.example.com/login.html"},
+		{"deep link wins over redirect url", &Service{Hostname: "adguard.example.com", RedirectURL: "https://adguard.example.com/login.html"}, "/#/settings", "https://adguard.example.com/#/settings"},
+		{"full url", &Service{Hostname: "nas.lan", URL: "http://nas.lan:5000"}, "", "http://nas.lan:5000"},
+		{"deep link under service path", &Service{Hostname: "example.com", URL: "https://example.com/grafana/"}, "/d/abc", "https://example.com/grafana/d/abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.service.TargetURL(tt.path)
+			if err != nil {
+				t.Fatalf("TargetURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TargetURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinTargetURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		suffix  string
+		want    string
+		wantErr bool
+	}{
+		{"no suffix keeps base", "http://nas.lan:5000/app?tab=1", "", "http://nas.lan:5000/app?tab=1", false},
+		{"port is kept", "https://nas.lan:5001", "/files", "https://nas.lan:5001/files", false},
+		{"path is joined", "https://example.com/grafana", "/d/abc", "https://example.com/grafana/d/abc", false},
+		{"trailing slash is not doubled", "https://example.com/grafana/", "/d/abc", "https://example.com/grafana/d/abc", false},
+		{"query replaces base query", "https://example.com/app?tab=1", "?tab=2", "https://example.com/app?tab=2", false},
+		{"path keeps base query", "https://example.com/app?tab=1", "/x", "https://example.com/app/x?tab=1", false},
+		{"authority is rejected", "http://nas.lan:5000", "//evil.example", "", true},
+		{"escaped slash is kept", "https://example.com/gitea", "/api/v1/repos/a%2Fb", "https://example.com/gitea/api/v1/repos/a%2Fb", false},
+		{"escaped base path is kept", "https://example.com/my%2Fapp", "/x", "https://example.com/my%2Fapp/x", false},
+		{"dot segments inside base path", "https://example.com/app", "/a/../b", "https://example.com/app/b", false},
+		{"dot segments leaving base path are rejected", "https://example.com/app", "/../../other", "", true},
+		{"dot segments to base sibling are rejected", "https://example.com/app", "/../app2", "", true},
+		{"dot segments at host root", "https://example.com", "/a/../../b/", "https://example.com/b/", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, err := url.Parse(tt.base)
+			if err != nil {
+				t.Fatalf("url.Parse() error = %v", err)
+			}
+			got, err := JoinTargetURL(base, tt.suffix)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsafePath) {
+					t.Errorf("JoinTargetURL() error = %v, want ErrUnsafePath", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("JoinTargetURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("JoinTargetURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceID(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"https://jellyfin.domain.ext", "jellyfin.domain.ext"},
+		{"https://Jellyfin.Domain.ext/", "jellyfin.domain.ext"},
+		{"https://nas.lan:5001", "nas.lan:5001"},
+		{"https://domain.ext/grafana/", "domain.ext/grafana"},
+		{"http://printer.lan", "http://printer.lan"},
+		{"HTTP://printer.lan:8080/admin", "http://printer.lan:8080/admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			target, err := url.Parse(tt.target)
+			if err != nil {
+				t.Fatalf("url.Parse() error = %v", err)
+			}
+			if got := ServiceID(target); got != tt.want {
+				t.Errorf("ServiceID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewService(t *testing.T) {
+	tests := []struct {
+		target   string
+		wantID   string
+		wantURL  string
+		wantName string
+		wantErr  bool
+	}{
+		{"https://Jellyfin.Domain.ext", "jellyfin.domain.ext", "https://jellyfin.domain.ext", "jellyfin", false},
+		{"https://domain.ext/grafana/#home", "domain.ext/grafana", "https://domain.ext/grafana/", "grafana", false},
+		{"http://nas.lan:5000", "http://nas.lan:5000", "http://nas.lan:5000", "nas", false},
+		{"ftp://files.lan", "", "", "", true},
+		{"https://", "", "", "", true},
+		{"not a url", "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			service, err := NewService(tt.target)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidServiceURL) {
+					t.Errorf("NewService() error = %v, want ErrInvalidServiceURL", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewService() error = %v", err)
+			}
+			if service.ID != tt.wantID || service.URL != tt.wantURL || service.Name != tt.wantName {
+				t.Errorf("NewService() = {ID: %q, URL: %q, Name: %q}, want {%q, %q, %q}",
+					service.ID, service.URL, service.Name, tt.wantID, tt.wantURL, tt.wantName)
+			}
+		})
+	}
+}
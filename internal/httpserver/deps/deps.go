@@ -3,6 +3,7 @@ package deps
 import (
 	"time"
 
+	"github.com/MrSnakeDoc/jump/internal/domain"
 	"github.com/MrSnakeDoc/jump/internal/index"
 	"github.com/MrSnakeDoc/jump/internal/logger"
 	"github.com/redis/go-redis/v9"
@@ -27,6 +28,20 @@ type Deps struct {
 	SkipTLSValidation     bool               // Skip TLS validation (useful for dev/local)
 	MaxCandidates         int                // Max number of candidates to validate
 	AllowedDomains        []string           // Allowed domain suffixes for redirects
+	HealthInterval        time.Duration      // Interval between background health probes (0 if disabled)
+	HealthStaleAfter      time.Duration      // Age after which probe results fall back to a live check
+	ChooserMargin         float64            // Score margin under which close candidates are offered in a chooser (0 = disabled)
+	ClientIdentity        string             // How clients are told apart for personal usage: none, ip, header, cookie
+	ClientHeader          string             // Trusted auth header holding the user name (header mode)
+	ClientCookie          string             // Cookie holding the client ID (cookie mode)
+	PersonalUsageWeight   float64            // Weight of the client's own usage in ranking
+	GlobalUsageWeight     float64            // Weight of the shared usage in ranking
+	FrecencyHalfLife      time.Duration      // Time after which usage counts for half (0 if decay disabled)
+	FrecencyDecayInterval time.Duration      // Interval between frecency decay passes
+	SearchEngines         map[string]string  // Named web search engines (name -> URL template with {q})
+	FallbackEngine        string             // Search engine used when nothing matches (empty = HomepageURL)
+	UnifiedSearch         bool               // Plain queries rank services and bookmarks together
+	Shortcuts             []domain.Shortcut  // Internal endpoints reachable with "/" queries (filled by routes.RegisterAll)
 	ReloadTrigger         chan struct{}      // Channel to trigger manual service reload
 	BookmarkReloadTrigger chan struct{}      // Channel to trigger manual bookmark reload (nil if bookmarks disabled)
 	// Add more shared deps later (Store, Version, etc.)
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+// maxAPIBody limits the size of JSON request bodies accepted by the /api/v1 endpoints
+const maxAPIBody = 1 << 20
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes a JSON response that is never cached
+func writeJSON(w http.ResponseWriter, status int, v interface{}, d deps.Deps) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		d.Logger.Debug("failed to write response", logger.Error(err))
+	}
+}
+
+// writeAPIError writes a JSON error response
+func writeAPIError(w http.ResponseWriter, status int, message string, d deps.Deps) {
+	writeJSON(w, status, apiError{Error: message}, d)
+}
+
+// requireJSON rejects requests whose Content-Type is not application/json (415).
+// Browsers send cross-site forms and text/plain bodies without a CORS preflight:
+// requiring JSON keeps other websites from driving the mutating endpoints.
+func requireJSON(w http.ResponseWriter, r *http.Request, d deps.Deps) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		writeAPIError(w, http.StatusUnsupportedMediaType, "content type must be application/json", d)
+		return false
+	}
+	return true
+}
+
+// decodeJSON decodes a JSON request body, rejecting unknown fields and oversized bodies
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	return decodeJSONLimit(w, r, v, maxAPIBody)
+}
+
+// decodeJSONLimit is decodeJSON for endpoints accepting bodies larger than maxAPIBody
+func decodeJSONLimit(w http.ResponseWriter, r *http.Request, v interface{}, limit int64) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
+)
+
+// Kinds of results offered by the chooser
+const (
+	chooserKindService  = "service"
+	chooserKindBookmark = "bookmark"
+)
+
+type chooserOption struct {
+	ID     string
+	Label  string
+	Detail string
+}
+
+type chooserPage struct {
+	Query   string
+	Kind    string
+	Options []chooserOption
+}
+
+var chooserTemplate = template.Must(template.New("chooser").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Jump: {{.Query}}</title>
+<style>
+  body { font-family: system-ui, sans-serif; background: #111; color: #eee; display: flex; justify-content: center; margin: 0; padding: 4rem 1rem; }
+  main { width: 100%; max-width: 32rem; }
+  h1 { font-size: 1rem; font-weight: normal; color: #aaa; }
+  ol { list-style: none; padding: 0; margin: 0; }
+  button { display: flex; gap: 1rem; align-items: baseline; width: 100%; margin: 0.25rem 0; padding: 0.75rem 1rem; border: 1px solid #333; border-radius: 0.5rem; background: #1b1b1b; color: inherit; font: inherit; text-align: left; cursor: pointer; }
+  button:hover, button:focus { border-color: #6cf; outline: none; background: #1f2a33; }
+  kbd { color: #6cf; }
+  .detail { color: #888; font-size: 0.85rem; margin-left: auto; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+  p { color: #666; font-size: 0.8rem; }
+</style>
+</head>
+<body>
+<main>
+<h1>Several results match <strong>{{.Query}}</strong></h1>
+<form method="post" action="/choose">
+<input type="hidden" name="q" value="{{.Query}}">
+<input type="hidden" name="kind" value="{{.Kind}}">
+<ol>
+{{- range $i, $o := .Options}}
+<li><button type="submit" name="id" value="{{$o.ID}}"{{if eq $i 0}} autofocus{{end}}><kbd>{{if lt $i 9}}{{inc $i}}{{end}}</kbd><span>{{$o.Label}}</span><span class="detail">{{$o.Detail}}</span></button></li>
+{{- end}}
+</ol>
+</form>
+<p>↑/↓ to move, Enter or 1–9 to pick. Your pick is remembered for this query.</p>
+</main>
+<script>
+  const buttons = Array.from(document.querySelectorAll("button[name=id]"));
+  document.addEventListener("keydown", (e) => {
+    const i = buttons.indexOf(document.activeElement);
+    if (e.key === "ArrowDown" || e.key === "j") {
+      buttons[(i + 1) % buttons.length].focus();
+      e.preventDefault();
+    } else if (e.key === "ArrowUp" || e.key === "k") {
+      buttons[(i - 1 + buttons.length) % buttons.length].focus();
+      e.preventDefault();
+    } else if (/^[1-9]$/.test(e.key) && buttons[e.key - 1]) {
+      buttons[e.key - 1].click();
+    }
+  });
+</script>
+</body>
+</html>
+`))
+
+// serviceChooser builds the chooser page for an ambiguous service query
+func serviceChooser(query string, res *serviceResolution) chooserPage {
+	page := chooserPage{Query: query, Kind: chooserKindService}
+	for _, check := range res.Choices {
+		service := check.Candidate.Service
+		label := service.DisplayName
+		if label == "" {
+			label = service.Name
+		}
+		if label == "" {
+			label = service.ID
+		}
+		page.Options = append(page.Options, chooserOption{
+			ID:     service.ID,
+			Label:  label,
+			Detail: service.ID,
+		})
+	}
+	return page
+}
+
+// bookmarkChooser builds the chooser page for an ambiguous bookmark query
+func bookmarkChooser(query string, res *bookmarkResolution) chooserPage {
+	page := chooserPage{Query: query, Kind: chooserKindBookmark}
+	for _, candidate := range res.Choices {
+		page.Options = append(page.Options, chooserOption{
+			ID:     candidate.Bookmark.ID,
+			Label:  "@" + candidate.Bookmark.Abbr,
+			Detail: candidate.Bookmark.URL,
+		})
+	}
+	return page
+}
+
+// renderChooser writes the chooser page
+func renderChooser(w http.ResponseWriter, page chooserPage, d deps.Deps) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := chooserTemplate.Execute(w, page); err != nil {
+		d.Logger.Debug("failed to write response", logger.Error(err))
+	}
+}
+
+// Choose records the pick made on the chooser page and redirects to it.
+// The next identical query redirects straight to the recorded pick.
+func Choose(d deps.Deps) http.HandlerFunc {
+	pipeline := newSearchPipeline(d)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+
+		query := strings.TrimSpace(r.PostForm.Get("q"))
+		id := r.PostForm.Get("id")
+
+		switch r.PostForm.Get("kind") {
+		case chooserKindService:
+			chooseService(w, r, query, id, pipeline, d)
+		case chooserKindBookmark:
+			chooseBookmark(w, r, strings.TrimSpace(strings.TrimPrefix(query, "@")), id, pipeline, d)
+		default:
+			http.Error(w, "invalid choice", http.StatusBadRequest)
+		}
+	}
+}
+
+// chooseService records a service pick, counts the usage and redirects
+func chooseService(w http.ResponseWriter, r *http.Request, query, id string, p *searchPipeline, d deps.Deps) {
+	ctx := r.Context()
+	client := clientIdentity(w, r, d)
+	parsed := domain.ParseQuery(query)
+
+	// Only accept services that actually match the query
+	var service *domain.Service
+	for _, candidate := range domain.RankCandidates(parsed, p.memIndex.GetAllServices()) {
+		if candidate.Service.ID == id && isAllowedService(candidate.Service, d.AllowedDomains) {
+			service = candidate.Service
+			break
+		}
+	}
+	if service == nil || parsed.Raw == "" {
+		http.Error(w, "invalid choice", http.StatusBadRequest)
+		return
+	}
+
+	key := resolutionKey(parsed.Raw, client)
+	if err := p.store.SaveChoice(ctx, redisstore.ChoiceRealmService, key, service.ID, redisstore.DefaultChoiceTTL); err != nil {
+		d.Logger.Warn("failed to record choice", logger.Error(err))
+	}
+
+	d.Logger.Info("service chosen",
+		logger.String("query", query),
+		logger.String("hostname", service.Hostname))
+
+	recordUsage(ctx, p, parsed.Raw, client, service.ID)
+	rememberRedirect(ctx, r, client, &serviceResolution{Query: query, Client: client, Parsed: parsed, Service: service}, p, d)
+
+	target, _ := p.serviceTarget(service, parsed.Path)
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+// chooseBookmark records a bookmark pick and redirects
+func chooseBookmark(w http.ResponseWriter, r *http.Request, query, id string, p *searchPipeline, d deps.Deps) {
+	res := &bookmarkResolution{Query: query, Client: clientIdentity(w, r, d)}
+
+	// Only accept bookmarks that actually match the query
+	var bookmark *domain.Bookmark
+	for _, candidate := range domain.RankBookmarkCandidates(res.Query, p.memIndex.GetAllBookmarks()) {
+		if candidate.Bookmark.ID == id {
+			bookmark = candidate.Bookmark
+			break
+		}
+	}
+	if bookmark == nil || res.Query == "" {
+		http.Error(w, "invalid choice", http.StatusBadRequest)
+		return
+	}
+
+	if err := p.store.SaveChoice(r.Context(), redisstore.ChoiceRealmBookmark, res.ChoiceKey(), bookmark.ID, redisstore.DefaultChoiceTTL); err != nil {
+		d.Logger.Warn("failed to record choice", logger.Error(err))
+	}
+
+	d.Logger.Info("bookmark chosen",
+		logger.String("query", res.Query),
+		logger.String("abbr", bookmark.Abbr),
+		logger.String("url", bookmark.URL))
+
+	recordBookmarkUsage(r.Context(), p, bookmark.ID)
+
+	http.Redirect(w, r, bookmark.URL, http.StatusSeeOther)
+}
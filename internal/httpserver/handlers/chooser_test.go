@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+)
+
+// choose posts a chooser form to the Choose handler
+func choose(handler http.Handler, form url.Values) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/choose", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestChoose(t *testing.T) {
+	d := newTestDeps(t, testServices()...)
+	d.MemoryIndex.UpdateBookmarks([]*domain.Bookmark{
+		{ID: "github", Abbr: "GH", URL: "https://github.com"},
+		{ID: "gitlab", Abbr: "GL", URL: "https://gitlab.com"},
+	})
+	handler := Choose(d)
+
+	tests := []struct {
+		name     string
+		form     url.Values
+		status   int
+		location string
+	}{
+		{
+			name:     "service pick",
+			form:     url.Values{"q": {"jel"}, "kind": {chooserKindService}, "id": {"jellyseerr.domain.ext"}},
+			status:   http.StatusSeeOther,
+			location: "https://jellyseerr.domain.ext",
+		},
+		{
+			name:     "bookmark pick",
+			form:     url.Values{"q": {"@gh"}, "kind": {chooserKindBookmark}, "id": {"github"}},
+			status:   http.StatusSeeOther,
+			location: "https://github.com",
+		},
+		{
+			name:   "service not matching the query",
+			form:   url.Values{"q": {"graf"}, "kind": {chooserKindService}, "id": {"jellyfin.domain.ext"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "unknown service",
+			form:   url.Values{"q": {"jel"}, "kind": {chooserKindService}, "id": {"evil.example"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "unknown kind",
+			form:   url.Values{"q": {"jel"}, "kind": {"other"}, "id": {"jellyfin.domain.ext"}},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := choose(handler, tt.form)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if got := w.Header().Get("Location"); got != tt.location {
+				t.Errorf("Location = %q, want %q", got, tt.location)
+			}
+		})
+	}
+}
+
+func TestChoose_CountsUsage(t *testing.T) {
+	d := newTestDeps(t, testServices()...)
+
+	choose(Choose(d), url.Values{"q": {"jel"}, "kind": {chooserKindService}, "id": {"jellyseerr.domain.ext"}})
+
+	service, _ := d.MemoryIndex.GetService("jellyseerr.domain.ext")
+	if service.Counter != 1 {
+		t.Errorf("counter = %d, want 1", service.Counter)
+	}
+}
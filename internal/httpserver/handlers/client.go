@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/utils"
+)
+
+// Client identity modes (JUMP_CLIENT_IDENTITY)
+const (
+	clientIdentityIP     = "ip"
+	clientIdentityHeader = "header"
+	clientIdentityCookie = "cookie"
+)
+
+// clientCookieMaxAge is the lifetime of the client ID cookie (400 days, the browser maximum)
+const clientCookieMaxAge = 400 * 24 * 60 * 60
+
+// clientIdentity returns the identity used for personal usage counters,
+// empty when personalization is disabled or the client cannot be identified.
+// In cookie mode, a new client ID is issued to clients that don't have one yet.
+func clientIdentity(w http.ResponseWriter, r *http.Request, d deps.Deps) string {
+	if client := existingClientIdentity(r, d); client != "" || d.ClientIdentity != clientIdentityCookie {
+		return client
+	}
+
+	id := newClientID()
+	if id != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     d.ClientCookie,
+			Value:    id,
+			Path:     "/",
+			MaxAge:   clientCookieMaxAge,
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+	return id
+}
+
+// existingClientIdentity returns the client identity without issuing a client ID,
+// for the read-only endpoints (resolve, explain) that must not set cookies
+func existingClientIdentity(r *http.Request, d deps.Deps) string {
+	switch d.ClientIdentity {
+	case clientIdentityIP:
+		return utils.ClientIP(r, d.TrustProxy)
+	case clientIdentityHeader:
+		if user := strings.TrimSpace(r.Header.Get(d.ClientHeader)); user != "" {
+			return "user:" + user
+		}
+		return ""
+	case clientIdentityCookie:
+		if cookie, err := r.Cookie(d.ClientCookie); err == nil && isClientID(cookie.Value) {
+			return cookie.Value
+		}
+		return ""
+	default:
+		return ""
+	}
+}
+
+// feedbackIdentity returns the identity the last redirect is recorded under:
+// the client identity, or the client IP when personalization is disabled
+func feedbackIdentity(r *http.Request, client string, d deps.Deps) string {
+	if client != "" {
+		return client
+	}
+	return "ip:" + utils.ClientIP(r, d.TrustProxy)
+}
+
+// newClientID generates a random client ID (empty if randomness is unavailable)
+func newClientID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// isClientID checks that a cookie value looks like an ID issued by newClientID
+func isClientID(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
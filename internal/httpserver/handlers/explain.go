@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+// Validation states reported per candidate
+const (
+	validationOK         = "ok"
+	validationFailed     = "failed"
+	validationSkipped    = "skipped"
+	validationNotChecked = "not_checked"
+)
+
+// Sources of a validation outcome
+const (
+	healthSourceProbe = "probe"
+	healthSourceLive  = "live"
+)
+
+type explainQuery struct {
+	Raw                string   `json:"raw"`
+	Group              string   `json:"group,omitempty"`
+	Fragments          []string `json:"fragments"`
+	HasDot             bool     `json:"has_dot"`
+	TopLevelFragments  []string `json:"top_level_fragments"`
+	SubdomainFragments []string `json:"subdomain_fragments"`
+	Path               string   `json:"path,omitempty"`
+}
+
+type explainCache struct {
+	Hit    bool   `json:"hit"`
+	Choice bool   `json:"choice"`
+	ID     string `json:"id,omitempty"`
+	Stale  bool   `json:"stale"`
+}
+
+type explainCandidate struct {
+	Rank          int     `json:"rank"`
+	ID            string  `json:"id"`
+	Hostname      string  `json:"hostname"`
+	LexicalScore  float64 `json:"lexical_score"`
+	UsageScore    float64 `json:"usage_score"`
+	PersonalScore float64 `json:"personal_score"`
+	LearnedScore  float64 `json:"learned_score"`
+	BoostScore    float64 `json:"boost_score"`
+	TotalScore    float64 `json:"total_score"`
+	Allowed       bool    `json:"allowed"`
+	Validation    string  `json:"validation"`
+	HealthSource  string  `json:"health_source,omitempty"`
+	Error         string  `json:"error,omitempty"`
+}
+
+type explainBookmarkCandidate struct {
+	Rank         int     `json:"rank"`
+	ID           string  `json:"id"`
+	Abbr         string  `json:"abbr"`
+	Name         string  `json:"name,omitempty"`
+	Category     string  `json:"category,omitempty"`
+	URL          string  `json:"url"`
+	LexicalScore float64 `json:"lexical_score"`
+	UsageScore   float64 `json:"usage_score"`
+	Score        float64 `json:"score"`
+}
+
+type explainShortcut struct {
+	Rank        int     `json:"rank"`
+	Path        string  `json:"path"`
+	Description string  `json:"description,omitempty"`
+	Safe        bool    `json:"safe"`
+	Score       float64 `json:"score"`
+}
+
+type explainMatch struct {
+	Rank  int     `json:"rank"`
+	Kind  string  `json:"kind"`
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+type explainSelection struct {
+	Kind      string `json:"kind"`
+	ID        string `json:"id,omitempty"`
+	URL       string `json:"url"`
+	PathError string `json:"path_error,omitempty"`
+}
+
+type explainResponse struct {
+	Query              string                     `json:"query"`
+	Realm              string                     `json:"realm"`
+	Parsed             *explainQuery              `json:"parsed,omitempty"`
+	Cache              *explainCache              `json:"cache,omitempty"`
+	Personalized       bool                       `json:"personalized,omitempty"`
+	Candidates         []explainCandidate         `json:"candidates,omitempty"`
+	BookmarkCandidates []explainBookmarkCandidate `json:"bookmark_candidates,omitempty"`
+	Matches            []explainMatch             `json:"matches,omitempty"`
+	Shortcuts          []explainShortcut          `json:"shortcuts,omitempty"`
+	Selected           *explainSelection          `json:"selected"`
+	Choices            []string                   `json:"choices,omitempty"`
+	Fallback           string                     `json:"fallback,omitempty"`
+	Reason             string                     `json:"reason"`
+}
+
+// wantsExplain reports whether the client asked for the ranking breakdown
+// (explain=1 or an Accept header preferring JSON)
+func wantsExplain(r *http.Request) bool {
+	switch strings.ToLower(r.URL.Query().Get("explain")) {
+	case "1", "true", "yes":
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+// writeExplain runs the search pipeline without side effects and writes the breakdown as JSON
+func writeExplain(w http.ResponseWriter, r *http.Request, query string, p *searchPipeline, d deps.Deps) {
+	response := explainResponse{Query: query}
+	engine, bangTarget, isBang := bangURL(query, d)
+
+	switch {
+	case query == "":
+		response.Realm = "none"
+		response.Reason = ReasonEmptyQuery
+	case isWrongFeedback(query):
+		// Reporting a wrong result has side effects: explain only names the realm
+		response.Realm = "feedback"
+		response.Reason = ReasonFeedback
+	case isBang:
+		response.Realm = "bang"
+		response.Reason = ReasonRanked
+		response.Selected = &explainSelection{Kind: "search", ID: engine, URL: bangTarget}
+	case strings.HasPrefix(query, "@"):
+		explainBookmarks(&response, p.resolveBookmark(r.Context(), strings.TrimPrefix(query, "@"), existingClientIdentity(r, d), true))
+	case strings.HasPrefix(query, domain.ShortcutPrefix):
+		explainShortcuts(&response, query, d)
+	case d.UnifiedSearch:
+		explainUnified(&response, p.resolveUnified(r.Context(), query, existingClientIdentity(r, d), true))
+	default:
+		explainServices(&response, p.resolveService(r.Context(), query, existingClientIdentity(r, d), true))
+	}
+
+	// Report where a miss would be sent
+	if response.Selected == nil && query != "" && response.Realm != "feedback" && response.Realm != "internal" {
+		response.Fallback = fallbackURL(strings.TrimPrefix(query, "@"), d)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		d.Logger.Debug("failed to write response", logger.Error(err))
+	}
+}
+
+// explainServices converts a service resolution into the explain response
+func explainServices(response *explainResponse, res *serviceResolution) {
+	response.Realm = "services"
+	response.Reason = res.Reason
+	response.Parsed = &explainQuery{
+		Raw:                res.Parsed.Raw,
+		Group:              res.Parsed.Group,
+		Fragments:          res.Parsed.Fragments,
+		HasDot:             res.Parsed.HasDot,
+		TopLevelFragments:  res.Parsed.TopLevelFragments,
+		SubdomainFragments: res.Parsed.SubdomainFragments,
+		Path:               res.Parsed.Path,
+	}
+	response.Personalized = res.Client != ""
+	response.Cache = &explainCache{
+		Hit:    res.CacheHit,
+		Choice: res.Choice,
+		ID:     res.CachedID,
+		Stale:  res.CacheStale,
+	}
+
+	response.Candidates = make([]explainCandidate, 0, len(res.Checks))
+	for _, check := range res.Checks {
+		candidate := explainCandidate{
+			Rank:          check.Rank,
+			ID:            check.Candidate.Service.ID,
+			Hostname:      check.Candidate.Service.Hostname,
+			LexicalScore:  check.Candidate.LexicalScore,
+			UsageScore:    check.Candidate.UsageScore,
+			PersonalScore: check.Candidate.PersonalScore,
+			LearnedScore:  check.Candidate.LearnedScore,
+			BoostScore:    check.Candidate.BoostScore,
+			TotalScore:    check.Candidate.TotalScore,
+			Allowed:       check.Allowed,
+			Validation:    validationState(check),
+		}
+		if check.Checked && !check.Skipped {
+			candidate.HealthSource = healthSourceLive
+			if check.Probed {
+				candidate.HealthSource = healthSourceProbe
+			}
+		}
+		if check.Err != nil {
+			candidate.Error = check.Err.Error()
+		}
+		response.Candidates = append(response.Candidates, candidate)
+	}
+
+	if res.Service != nil {
+		response.Selected = &explainSelection{
+			Kind: "service",
+			ID:   res.Service.ID,
+			URL:  res.TargetURL,
+		}
+		if res.PathErr != nil {
+			response.Selected.PathError = res.PathErr.Error()
+		}
+	}
+
+	for _, check := range res.Choices {
+		response.Choices = append(response.Choices, check.Candidate.Service.ID)
+	}
+}
+
+// explainUnified converts a unified resolution into the explain response
+func explainUnified(response *explainResponse, res *unifiedResolution) {
+	explainServices(response, res.Services)
+	response.Realm = "unified"
+
+	response.BookmarkCandidates = explainBookmarkCandidates(res.Bookmarks)
+
+	response.Matches = make([]explainMatch, 0, len(res.Matches))
+	for i, match := range res.Matches {
+		response.Matches = append(response.Matches, explainMatch{
+			Rank:  i + 1,
+			Kind:  match.Kind,
+			ID:    match.ID(),
+			Score: match.Score,
+		})
+	}
+
+	if res.Bookmark != nil {
+		response.Reason = ReasonRanked
+		response.Choices = nil
+		response.Selected = &explainSelection{
+			Kind: "bookmark",
+			ID:   res.Bookmark.Bookmark.ID,
+			URL:  res.Bookmark.Bookmark.URL,
+		}
+	}
+}
+
+// explainBookmarks converts a bookmark resolution into the explain response
+func explainBookmarks(response *explainResponse, res *bookmarkResolution) {
+	response.Realm = "bookmarks"
+	response.Reason = res.Reason
+	if res.Query != "" {
+		response.Cache = &explainCache{
+			Hit:    res.CacheHit,
+			Choice: res.Choice,
+			ID:     res.CachedID,
+			Stale:  res.CacheStale,
+		}
+	}
+
+	response.BookmarkCandidates = explainBookmarkCandidates(res.Candidates)
+
+	if res.Bookmark != nil {
+		response.Selected = &explainSelection{
+			Kind: "bookmark",
+			ID:   res.Bookmark.ID,
+			URL:  res.Bookmark.URL,
+		}
+	}
+
+	for _, candidate := range res.Choices {
+		response.Choices = append(response.Choices, candidate.Bookmark.ID)
+	}
+}
+
+// explainBookmarkCandidates lists ranked bookmark candidates for the explain response
+func explainBookmarkCandidates(candidates []*domain.BookmarkCandidate) []explainBookmarkCandidate {
+	list := make([]explainBookmarkCandidate, 0, len(candidates))
+	for i, candidate := range candidates {
+		list = append(list, explainBookmarkCandidate{
+			Rank:         i + 1,
+			ID:           candidate.Bookmark.ID,
+			Abbr:         candidate.Bookmark.Abbr,
+			Name:         candidate.Bookmark.Name,
+			Category:     candidate.Bookmark.Category,
+			URL:          candidate.Bookmark.URL,
+			LexicalScore: candidate.LexicalScore,
+			UsageScore:   candidate.UsageScore,
+			Score:        candidate.Score,
+		})
+	}
+	return list
+}
+
+// explainShortcuts ranks the shortcuts for a "/" query ("/" alone lists them all)
+func explainShortcuts(response *explainResponse, query string, d deps.Deps) {
+	response.Realm = "internal"
+
+	if strings.TrimSpace(query) == domain.ShortcutPrefix {
+		response.Reason = ReasonAmbiguous
+		response.Shortcuts = make([]explainShortcut, 0, len(d.Shortcuts))
+		for i, shortcut := range d.Shortcuts {
+			response.Shortcuts = append(response.Shortcuts, explainShortcut{
+				Rank:        i + 1,
+				Path:        shortcut.Path,
+				Description: shortcut.Description,
+				Safe:        shortcut.Safe,
+			})
+			if shortcut.Safe {
+				response.Choices = append(response.Choices, shortcut.Path)
+			}
+		}
+		return
+	}
+
+	res := resolveShortcut(query, d.Shortcuts)
+	response.Reason = res.Reason
+	if res.Reason == ReasonNoMatch {
+		// Internal misses go to the homepage, never to the web search engine
+		response.Fallback = d.HomepageURL
+	}
+
+	response.Shortcuts = make([]explainShortcut, 0, len(res.Candidates))
+	for i, candidate := range res.Candidates {
+		response.Shortcuts = append(response.Shortcuts, explainShortcut{
+			Rank:        i + 1,
+			Path:        candidate.Shortcut.Path,
+			Description: candidate.Shortcut.Description,
+			Safe:        candidate.Shortcut.Safe,
+			Score:       candidate.Score,
+		})
+	}
+
+	if res.Shortcut != nil {
+		response.Selected = &explainSelection{Kind: "internal", ID: res.Shortcut.Name, URL: res.Shortcut.Path}
+	}
+
+	for _, shortcut := range res.Choices {
+		response.Choices = append(response.Choices, shortcut.Path)
+	}
+}
+
+// validationState summarizes the validation outcome of a candidate
+func validationState(check *candidateCheck) string {
+	switch {
+	case !check.Checked:
+		return validationNotChecked
+	case check.Skipped:
+		return validationSkipped
+	case check.Err != nil:
+		return validationFailed
+	default:
+		return validationOK
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// explain runs a search request and decodes the explain response
+func explain(t *testing.T, handler http.Handler, r *http.Request) explainResponse {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (explain never redirects)", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+
+	var response explainResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid explain response %s: %v", w.Body.String(), err)
+	}
+	return response
+}
+
+func TestExplain(t *testing.T) {
+	handler := Search(newTestDeps(t, testServices()...))
+
+	response := explain(t, handler, httptest.NewRequest(http.MethodGet, "/search?q=jellyfin&explain=1", nil))
+
+	if response.Realm != "services" || response.Reason != ReasonRanked {
+		t.Errorf("realm, reason = %q, %q", response.Realm, response.Reason)
+	}
+	if response.Selected == nil || response.Selected.ID != "jellyfin.domain.ext" || response.Selected.URL != "https://jellyfin.domain.ext" {
+		t.Fatalf("selected = %+v, want jellyfin", response.Selected)
+	}
+	if len(response.Candidates) == 0 || response.Candidates[0].ID != "jellyfin.domain.ext" {
+		t.Errorf("candidates = %+v, want jellyfin first", response.Candidates)
+	}
+	if response.Cache == nil || response.Cache.Hit {
+		t.Errorf("cache = %+v, want a miss", response.Cache)
+	}
+}
+
+func TestExplain_AcceptHeader(t *testing.T) {
+	handler := Search(newTestDeps(t, testServices()...))
+
+	r := httptest.NewRequest(http.MethodGet, "/search?q=graf", nil)
+	r.Header.Set("Accept", "application/json")
+	response := explain(t, handler, r)
+
+	if response.Selected == nil || response.Selected.ID != "grafana.domain.ext" {
+		t.Errorf("selected = %+v, want grafana", response.Selected)
+	}
+}
+
+func TestExplain_NoMatch(t *testing.T) {
+	d := newTestDeps(t, testServices()...)
+	handler := Search(d)
+
+	response := explain(t, handler, httptest.NewRequest(http.MethodGet, "/search?q=zzzz&explain=1", nil))
+
+	if response.Selected != nil {
+		t.Errorf("selected = %+v, want none", response.Selected)
+	}
+	if response.Reason != ReasonNoMatch {
+		t.Errorf("reason = %q, want %q", response.Reason, ReasonNoMatch)
+	}
+	if response.Fallback != d.HomepageURL {
+		t.Errorf("fallback = %q, want %q", response.Fallback, d.HomepageURL)
+	}
+}
+
+func TestExplain_NoClientCookie(t *testing.T) {
+	d := newTestDeps(t, testServices()...)
+	d.ClientIdentity = clientIdentityCookie
+	d.ClientCookie = "jump_client"
+	handler := Search(d)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search?q=jellyfin&explain=1", nil))
+
+	if cookies := w.Result().Cookies(); len(cookies) != 0 {
+		t.Errorf("cookies = %v, want none", cookies)
+	}
+}
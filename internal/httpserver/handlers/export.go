@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
+)
+
+// maxImportBody limits the size of an imported snapshot
+const maxImportBody = 32 << 20
+
+// Export returns the learning state as a versioned JSON snapshot
+func Export(d deps.Deps) http.HandlerFunc {
+	store := redisstore.NewStore(d.RedisClient)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		snapshot, err := store.Export(r.Context())
+		if err != nil {
+			d.Logger.Error("failed to export learning state", logger.Error(err))
+			writeAPIError(w, http.StatusInternalServerError, "failed to export learning state", d)
+			return
+		}
+
+		d.Logger.Info("learning state exported",
+			logger.Int("services", len(snapshot.Services)),
+			logger.Int("bookmarks", len(snapshot.Bookmarks)))
+
+		filename := fmt.Sprintf("jump-%s.json", snapshot.ExportedAt.Format("20060102-150405"))
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+		writeJSON(w, http.StatusOK, snapshot, d)
+	}
+}
+
+// Import restores a snapshot produced by Export (?mode=merge, the default, or ?mode=replace),
+// then reloads the index from Redis and re-applies Homepage on top of it
+func Import(d deps.Deps) http.HandlerFunc {
+	store := redisstore.NewStore(d.RedisClient)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !requireJSON(w, r, d) {
+			return
+		}
+
+		mode := strings.TrimSpace(r.URL.Query().Get("mode"))
+		if mode == "" {
+			mode = redisstore.ImportModeMerge
+		}
+
+		var snapshot redisstore.Snapshot
+		if err := decodeJSONLimit(w, r, &snapshot, maxImportBody); err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid snapshot", d)
+			return
+		}
+
+		summary, err := store.Import(r.Context(), &snapshot, mode)
+		switch {
+		case errors.Is(err, redisstore.ErrUnsupportedSnapshot), errors.Is(err, redisstore.ErrInvalidSnapshot),
+			errors.Is(err, redisstore.ErrInvalidImportMode):
+			writeAPIError(w, http.StatusBadRequest, err.Error(), d)
+			return
+		case err != nil:
+			d.Logger.Error("failed to import learning state", logger.Error(err))
+			writeAPIError(w, http.StatusInternalServerError, "failed to import learning state", d)
+			return
+		}
+
+		if services, err := store.GetAllServices(r.Context()); err == nil {
+			d.MemoryIndex.UpdateServices(services)
+		} else {
+			d.Logger.Warn("failed to reload services after import", logger.Error(err))
+		}
+		if bookmarks, err := store.GetAllBookmarks(r.Context()); err == nil {
+			d.MemoryIndex.UpdateBookmarks(bookmarks)
+		} else {
+			d.Logger.Warn("failed to reload bookmarks after import", logger.Error(err))
+		}
+
+		// Imported entries may be stale: let Homepage restore the current definitions
+		select {
+		case d.ReloadTrigger <- struct{}{}:
+		default:
+		}
+		select {
+		case d.BookmarkReloadTrigger <- struct{}{}:
+		default:
+		}
+
+		d.Logger.Info("learning state imported",
+			logger.String("mode", summary.Mode),
+			logger.Int("services", summary.Services),
+			logger.Int("bookmarks", summary.Bookmarks),
+			logger.Int("entries", summary.Entries))
+
+		writeJSON(w, http.StatusOK, summary, d)
+	}
+}
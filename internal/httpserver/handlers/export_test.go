@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExport_RedisUnavailable(t *testing.T) {
+	handler := Export(newTestDeps(t, testServices()...))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/export", nil))
+
+	// A partial snapshot would silently lose state on import: fail instead
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	if w.Header().Get("Content-Disposition") != "" {
+		t.Error("a failed export must not be offered as a download")
+	}
+	var response apiError
+	decodeResponse(t, w, &response)
+	if response.Error == "" {
+		t.Error("error message is empty")
+	}
+}
+
+func TestImport_InvalidRequests(t *testing.T) {
+	handler := Import(newTestDeps(t, testServices()...))
+
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"form content type", "/api/v1/import", "application/x-www-form-urlencoded", `{"version": 1}`, http.StatusUnsupportedMediaType},
+		{"malformed body", "/api/v1/import", "application/json", `{"version": `, http.StatusBadRequest},
+		{"unknown field", "/api/v1/import", "application/json", `{"version": 1, "extra": true}`, http.StatusBadRequest},
+		{"unsupported version", "/api/v1/import", "application/json", `{"version": 99}`, http.StatusBadRequest},
+		{"invalid mode", "/api/v1/import?mode=wipe", "application/json", `{"version": 1}`, http.StatusBadRequest},
+		{"duplicate service", "/api/v1/import", "application/json", `{"version": 1, "services": [{"ID": "a"}, {"ID": "a"}]}`, http.StatusBadRequest},
+		{"redis unavailable", "/api/v1/import?mode=replace", "application/json", `{"version": 1}`, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+		})
+	}
+}
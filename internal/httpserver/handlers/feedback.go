@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
+)
+
+type feedbackResponse struct {
+	Query   string `json:"query,omitempty"`
+	Demoted string `json:"demoted,omitempty"`
+	Next    string `json:"next,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// wrongReport is the outcome of reporting the last redirect of a client as wrong
+type wrongReport struct {
+	Last *redisstore.LastRedirect
+	Next *serviceResolution // resolution of the same query without the wrong service
+}
+
+// Feedback reports the last redirect of the client as wrong.
+// The cached resolution is dropped and the query/service pair demoted.
+// With redirect=1, the client is sent to the next candidate instead of getting JSON.
+func Feedback(d deps.Deps) http.HandlerFunc {
+	pipeline := newSearchPipeline(d)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+
+		client := clientIdentity(w, r, d)
+		report := reportWrong(r.Context(), r, client, pipeline, d)
+
+		switch strings.ToLower(r.Form.Get("redirect")) {
+		case "1", "true", "yes":
+			http.Redirect(w, r, followNext(r.Context(), r, client, report, pipeline, d), http.StatusSeeOther)
+			return
+		}
+
+		response := feedbackResponse{}
+		status := http.StatusOK
+		if report == nil {
+			status = http.StatusNotFound
+			response.Error = "no recent redirect to report"
+		} else {
+			response.Query = report.Last.Query
+			response.Demoted = report.Last.ServiceID
+			if report.Next.Service != nil {
+				response.Next = report.Next.Service.ID
+				response.URL = report.Next.TargetURL
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			d.Logger.Debug("failed to write response", logger.Error(err))
+		}
+	}
+}
+
+// isWrongFeedback reports whether a query is the "!wrong" feedback bang
+func isWrongFeedback(query string) bool {
+	name, _, ok := domain.ParseBang(query)
+	return ok && name == domain.FeedbackBang
+}
+
+var wrongFeedbackTemplate = template.Must(template.New("feedback").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Jump: wrong result</title>
+<style>
+  body { font-family: system-ui, sans-serif; background: #111; color: #eee; display: flex; justify-content: center; margin: 0; padding: 4rem 1rem; }
+  main { width: 100%; max-width: 32rem; }
+  h1 { font-size: 1rem; font-weight: normal; color: #aaa; }
+  button { width: 100%; margin: 0.25rem 0; padding: 0.75rem 1rem; border: 1px solid #333; border-radius: 0.5rem; background: #1b1b1b; color: inherit; font: inherit; text-align: left; cursor: pointer; }
+  button:hover, button:focus { border-color: #6cf; outline: none; background: #1f2a33; }
+  p { color: #666; font-size: 0.8rem; }
+</style>
+</head>
+<body>
+<main>
+<h1>Was your last result wrong?</h1>
+<form method="post" action="/feedback">
+<input type="hidden" name="redirect" value="1">
+<button type="submit" autofocus>Report it and go to the next result</button>
+</form>
+<p>Enter to confirm. The query stops resolving to that result.</p>
+</main>
+</body>
+</html>
+`))
+
+// handleWrongFeedback handles the "!wrong" query with a page confirming the report.
+// Reporting changes state, so it is only done by the form POST to /feedback:
+// a link or an image on another website cannot report on behalf of the client.
+func handleWrongFeedback(w http.ResponseWriter, d deps.Deps) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := wrongFeedbackTemplate.Execute(w, nil); err != nil {
+		d.Logger.Debug("failed to write response", logger.Error(err))
+	}
+}
+
+// reportWrong drops the cached resolution and recorded pick of the client's last redirect,
+// demotes the query/service pair and resolves the query again without that service.
+// Returns nil when the client has no recent redirect.
+func reportWrong(ctx context.Context, r *http.Request, client string, p *searchPipeline, d deps.Deps) *wrongReport {
+	identity := feedbackIdentity(r, client, d)
+
+	last, err := p.store.GetLastRedirect(ctx, identity)
+	if err != nil {
+		d.Logger.Warn("failed to load last redirect", logger.Error(err))
+		return nil
+	}
+	if last == nil {
+		d.Logger.Info("wrong result reported without a recent redirect")
+		return nil
+	}
+
+	_ = p.store.InvalidateCache(ctx, last.Key)
+	_ = p.store.DeleteChoice(ctx, redisstore.ChoiceRealmService, last.Key)
+	if err := p.store.Demote(ctx, domain.ParseQuery(last.Query).Raw, last.ServiceID); err != nil {
+		d.Logger.Warn("failed to demote resolution", logger.Error(err))
+	}
+
+	d.Logger.Info("wrong result reported",
+		logger.String("query", last.Query),
+		logger.String("service", last.ServiceID))
+
+	return &wrongReport{
+		Last: last,
+		Next: p.resolveNext(ctx, last.Query, client, last.ServiceID),
+	}
+}
+
+// followNext commits the redirect to the next candidate of a report and returns its URL.
+// Without a report or a next candidate, the fallback URL is returned.
+func followNext(ctx context.Context, r *http.Request, client string, report *wrongReport, p *searchPipeline, d deps.Deps) string {
+	if report == nil {
+		return d.HomepageURL
+	}
+
+	next := report.Next
+	if next.Service == nil {
+		_ = p.store.DeleteLastRedirect(ctx, feedbackIdentity(r, client, d))
+		return fallbackURL(report.Last.Query, d)
+	}
+
+	d.Logger.Info("redirecting to next candidate",
+		logger.String("query", report.Last.Query),
+		logger.String("hostname", next.Service.Hostname))
+
+	recordUsage(ctx, p, next.Parsed.Raw, client, next.Service.ID)
+	_ = p.store.CacheResolution(ctx, next.CacheKey(), next.Service.ID, redisstore.DefaultCacheTTL)
+	rememberRedirect(ctx, r, client, next, p, d)
+
+	return next.TargetURL
+}
+
+// rememberRedirect records the redirect of a service resolution so it can be reported wrong (best effort)
+func rememberRedirect(ctx context.Context, r *http.Request, client string, res *serviceResolution, p *searchPipeline, d deps.Deps) {
+	_ = p.store.SaveLastRedirect(ctx, feedbackIdentity(r, client, d), &redisstore.LastRedirect{
+		Query:     res.Query,
+		Key:       res.CacheKey(),
+		ServiceID: res.Service.ID,
+		At:        time.Now(),
+	})
+}
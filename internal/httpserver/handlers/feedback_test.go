@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFeedback_NoRecentRedirect(t *testing.T) {
+	handler := Feedback(newTestDeps(t, testServices()...))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/feedback", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", w.Code)
+	}
+	var response feedbackResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid feedback response %s: %v", w.Body.String(), err)
+	}
+	if response.Error == "" || response.Demoted != "" {
+		t.Errorf("response = %+v, want an error and nothing demoted", response)
+	}
+}
+
+func TestFeedback_RedirectWithoutReport(t *testing.T) {
+	d := newTestDeps(t, testServices()...)
+	handler := Feedback(d)
+
+	form := url.Values{"redirect": {"1"}}
+	r := httptest.NewRequest(http.MethodPost, "/feedback", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d, want 303", w.Code)
+	}
+	if got := w.Header().Get("Location"); got != d.HomepageURL {
+		t.Errorf("Location = %q, want %q", got, d.HomepageURL)
+	}
+}
+
+func TestSearch_WrongFeedbackAsksToConfirm(t *testing.T) {
+	handler := Search(newTestDeps(t, testServices()...))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search?q="+url.QueryEscape("!wrong"), nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (reporting is left to the confirmation form)", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/html") {
+		t.Errorf("Content-Type = %q, want text/html", got)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `method="post" action="/feedback"`) || !strings.Contains(body, `name="redirect" value="1"`) {
+		t.Errorf("confirmation page does not post to /feedback:\n%s", body)
+	}
+}
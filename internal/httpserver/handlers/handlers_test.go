@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/index"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+// newTestDeps returns handler dependencies serving the given services from the memory index.
+// Redis is unreachable: store calls fail fast, like during an outage, and handlers degrade to the index.
+func newTestDeps(t *testing.T, services ...*domain.Service) deps.Deps {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{
+		Addr:               "127.0.0.1:1",
+		MaxRetries:         -1,
+		DialerRetries:      1,
+		DialerRetryTimeout: time.Millisecond,
+		DialTimeout:        100 * time.Millisecond,
+	})
+	t.Cleanup(func() { _ = client.Close() })
+
+	memIndex := index.NewMemoryIndex()
+	memIndex.UpdateServices(services)
+
+	return deps.Deps{
+		Logger:            logger.New("error", false),
+		TimeNow:           time.Now,
+		RedisClient:       client,
+		MemoryIndex:       memIndex,
+		HomepageURL:       "https://home.domain.ext",
+		SkipTLSValidation: true,
+		MaxCandidates:     3,
+		AllowedDomains:    []string{"domain.ext"},
+	}
+}
+
+// testServices returns a small set of services reachable under domain.ext
+func testServices() []*domain.Service {
+	return []*domain.Service{
+		{ID: "jellyfin.domain.ext", Name: "jellyfin", Hostname: "jellyfin.domain.ext"},
+		{ID: "jellyseerr.domain.ext", Name: "jellyseerr", Hostname: "jellyseerr.domain.ext"},
+		{ID: "grafana.domain.ext", Name: "grafana", Hostname: "grafana.domain.ext"},
+	}
+}
@@ -6,16 +6,26 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/MrSnakeDoc/jump/internal/domain"
 	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
 )
 
 type componentStatus struct {
-	OK             bool   `json:"ok"`
-	ServicesLoaded *int   `json:"services_loaded,omitempty"`
-	LastReload     string `json:"last_reload,omitempty"`
-	Mode           string `json:"mode,omitempty"`
-	Impact         string `json:"impact,omitempty"`
-	Error          string `json:"error,omitempty"`
+	OK                bool     `json:"ok"`
+	ServicesLoaded    *int     `json:"services_loaded,omitempty"`
+	LastReload        string   `json:"last_reload,omitempty"`
+	BookmarksLoaded   *int     `json:"bookmarks_loaded,omitempty"`
+	BookmarksRejected *int     `json:"bookmarks_rejected,omitempty"`
+	ServicesUp        *int     `json:"services_up,omitempty"`
+	ServicesDown      *int     `json:"services_down,omitempty"`
+	LastProbe         string   `json:"last_probe,omitempty"`
+	HalfLife          string   `json:"half_life,omitempty"`
+	DecayInterval     string   `json:"decay_interval,omitempty"`
+	DecayFactor       *float64 `json:"decay_factor,omitempty"`
+	LastDecay         string   `json:"last_decay,omitempty"`
+	Mode              string   `json:"mode,omitempty"`
+	Impact            string   `json:"impact,omitempty"`
+	Error             string   `json:"error,omitempty"`
 }
 
 type infraResponse struct {
@@ -45,7 +55,10 @@ func Infra(d deps.Deps) http.HandlerFunc {
 				ServicesLoaded: &servicesCount,
 				LastReload:     lastReloadStr,
 			},
-			"redis": redisStatus,
+			"bookmarks": checkBookmarks(d),
+			"redis":     redisStatus,
+			"health":    checkHealth(d),
+			"frecency":  checkFrecency(d),
 			"resolver": {
 				OK:   true,
 				Mode: "fuzzy+usage-learning",
@@ -109,3 +122,81 @@ func checkRedis(d deps.Deps) componentStatus {
 		Error:  "none",
 	}
 }
+
+func checkHealth(d deps.Deps) componentStatus {
+	if d.HealthInterval <= 0 {
+		return componentStatus{
+			OK:     true,
+			Mode:   "disabled",
+			Impact: "live-validation-on-request",
+		}
+	}
+
+	up, down := d.MemoryIndex.HealthCounts()
+	lastProbe := d.MemoryIndex.GetLastProbe()
+	lastProbeStr := "never"
+	if !lastProbe.IsZero() {
+		lastProbeStr = lastProbe.Format("2006-01-02 15:04:05")
+	}
+
+	return componentStatus{
+		OK:           down == 0,
+		Mode:         "background",
+		ServicesUp:   &up,
+		ServicesDown: &down,
+		LastProbe:    lastProbeStr,
+	}
+}
+
+func checkFrecency(d deps.Deps) componentStatus {
+	if d.FrecencyHalfLife <= 0 {
+		return componentStatus{
+			OK:     true,
+			Mode:   "disabled",
+			Impact: "usage-never-expires",
+		}
+	}
+
+	// Multiplier applied to usage at each decay pass
+	factor := domain.DecayFactor(d.FrecencyDecayInterval, d.FrecencyHalfLife)
+	lastDecay := d.MemoryIndex.GetLastDecay()
+	lastDecayStr := "never"
+	if !lastDecay.IsZero() {
+		lastDecayStr = lastDecay.Format("2006-01-02 15:04:05")
+	}
+
+	return componentStatus{
+		OK:            true,
+		Mode:          "decay",
+		HalfLife:      d.FrecencyHalfLife.String(),
+		DecayInterval: d.FrecencyDecayInterval.String(),
+		DecayFactor:   &factor,
+		LastDecay:     lastDecayStr,
+	}
+}
+
+func checkBookmarks(d deps.Deps) componentStatus {
+	if d.BookmarkReloadTrigger == nil {
+		return componentStatus{
+			OK:     true,
+			Mode:   "disabled",
+			Impact: "bookmark-search-disabled",
+		}
+	}
+
+	loaded := d.MemoryIndex.BookmarkCount()
+	rejected := d.MemoryIndex.RejectedBookmarks()
+	lastReload := d.MemoryIndex.GetLastBookmarkReload()
+	lastReloadStr := "never"
+	if !lastReload.IsZero() {
+		lastReloadStr = lastReload.Format("2006-01-02 15:04:05")
+	}
+
+	return componentStatus{
+		OK:                rejected == 0,
+		Mode:              "policy",
+		BookmarksLoaded:   &loaded,
+		BookmarksRejected: &rejected,
+		LastReload:        lastReloadStr,
+	}
+}
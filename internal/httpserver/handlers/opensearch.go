@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+const (
+	openSearchNamespace   = "http://a9.com/-/spec/opensearch/1.1/"
+	openSearchContentType = "application/opensearchdescription+xml"
+	suggestionsMIMEType   = "application/x-suggestions+json"
+)
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Method   string `xml:"method,attr,omitempty"`
+	Rel      string `xml:"rel,attr,omitempty"`
+	Template string `xml:"template,attr"`
+}
+
+type openSearchDescription struct {
+	XMLName        xml.Name        `xml:"OpenSearchDescription"`
+	Namespace      string          `xml:"xmlns,attr"`
+	ShortName      string          `xml:"ShortName"`
+	Description    string          `xml:"Description"`
+	InputEncoding  string          `xml:"InputEncoding"`
+	OutputEncoding string          `xml:"OutputEncoding"`
+	URLs           []openSearchURL `xml:"Url"`
+}
+
+// OpenSearch serves the OpenSearch description document so browsers can
+// register Jump as a search engine with live suggestions.
+func OpenSearch(d deps.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		base := fmt.Sprintf("https://%s", r.Host)
+
+		description := openSearchDescription{
+			Namespace:      openSearchNamespace,
+			ShortName:      "Jump",
+			Description:    "Fuzzy launcher for self-hosted services",
+			InputEncoding:  "UTF-8",
+			OutputEncoding: "UTF-8",
+			URLs: []openSearchURL{
+				{Type: "text/html", Method: http.MethodGet, Template: base + "/search?q={searchTerms}"},
+				{Type: suggestionsMIMEType, Method: http.MethodGet, Template: base + "/suggest?q={searchTerms}"},
+				{Type: openSearchContentType, Rel: "self", Template: base + "/opensearch.xml"},
+			},
+		}
+
+		w.Header().Set("Content-Type", openSearchContentType+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(xml.Header)); err != nil {
+			d.Logger.Debug("failed to write response", logger.Error(err))
+			return
+		}
+		enc := xml.NewEncoder(w)
+		enc.Indent("", "  ")
+		if err := enc.Encode(description); err != nil {
+			d.Logger.Debug("failed to write response", logger.Error(err))
+		}
+	}
+}
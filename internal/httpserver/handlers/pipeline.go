@@ -0,0 +1,635 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/index"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
+)
+
+// Resolution reasons (reported by explain mode)
+const (
+	ReasonCacheHit           = "cache_hit"
+	ReasonChoice             = "user_choice"
+	ReasonAmbiguous          = "ambiguous"
+	ReasonRanked             = "ranked"
+	ReasonNoServices         = "no_services"
+	ReasonNoBookmarks        = "no_bookmarks"
+	ReasonNoMatch            = "no_match"
+	ReasonNoHealthyCandidate = "no_healthy_candidate"
+	ReasonEmptyQuery         = "empty_query"
+	ReasonFeedback           = "feedback"
+)
+
+// searchPipeline resolves queries against the index.
+// It never writes anything: redirect handlers commit the outcome
+// (usage counters, cache) once a target has been chosen.
+type searchPipeline struct {
+	d        deps.Deps
+	store    *redisstore.Store
+	memIndex *index.MemoryIndex
+}
+
+// newSearchPipeline creates a pipeline from the shared dependencies
+func newSearchPipeline(d deps.Deps) *searchPipeline {
+	return &searchPipeline{
+		d:        d,
+		store:    redisstore.NewStore(d.RedisClient),
+		memIndex: d.MemoryIndex,
+	}
+}
+
+// candidateCheck records the allowlist and validation outcome for a ranked candidate
+type candidateCheck struct {
+	Candidate *domain.Candidate
+	Rank      int
+	Allowed   bool
+	Checked   bool  // validation was attempted (or skipped by config)
+	Skipped   bool  // validation disabled in config
+	Probed    bool  // outcome taken from a fresh background probe instead of a live check
+	Err       error // validation error, nil when healthy
+}
+
+// serviceResolution is the outcome of resolving a service query
+type serviceResolution struct {
+	Query      string
+	Client     string // client identity for personal usage, empty when disabled
+	Parsed     *domain.Query
+	CacheHit   bool   // a valid cached resolution (or recorded pick) was used
+	Choice     bool   // the cached resolution is a pick recorded from the chooser
+	CachedID   string // cached service ID, if any
+	CacheStale bool   // cached service failed validation and should be invalidated
+	Checks     []*candidateCheck
+	Service    *domain.Service // selected service, nil when nothing matched
+	Score      float64
+	Reason     string
+	Choices    []*candidateCheck // selected service and close contenders, when ambiguous
+	TargetURL  string            // redirect target of the selected service, deep-link path included
+	PathErr    error             // deep-link path rejected as unsafe (target falls back to the bare host)
+}
+
+// CacheKey returns the normalized query used for the resolution cache and chooser picks
+// (the deep-link path is not part of it)
+func (res *serviceResolution) CacheKey() string {
+	return resolutionKey(res.Parsed.Raw, res.Client)
+}
+
+// resolutionKey scopes a normalized query to a client when usage is personalized,
+// so one client's cached resolutions and picks don't leak into another's ranking
+func resolutionKey(raw, client string) string {
+	if raw == "" || client == "" {
+		return raw
+	}
+	return raw + "@" + redisstore.ClientHash(client)
+}
+
+// Ambiguous reports whether the user should pick among close candidates
+func (res *serviceResolution) Ambiguous() bool {
+	return len(res.Choices) > 1
+}
+
+// bookmarkResolution is the outcome of resolving a bookmark query
+type bookmarkResolution struct {
+	Query      string
+	Client     string // client identity for personal picks, empty when disabled
+	CacheHit   bool   // a cached resolution (or recorded pick) was used
+	Choice     bool   // the bookmark is a pick recorded from the chooser
+	CachedID   string // cached bookmark ID, if any
+	CacheStale bool   // cached bookmark is gone or disabled and should be invalidated
+	Candidates []*domain.BookmarkCandidate
+	Bookmark   *domain.Bookmark // selected bookmark, nil when nothing matched
+	Score      float64
+	Reason     string
+	Choices    []*domain.BookmarkCandidate // selected bookmark and close contenders, when ambiguous
+}
+
+// ChoiceKey returns the normalized query used to record chooser picks,
+// scoped to the client like service resolutions
+func (res *bookmarkResolution) ChoiceKey() string {
+	return resolutionKey(strings.ToLower(res.Query), res.Client)
+}
+
+// CacheKey returns the key of the resolution cache, prefixed with @
+// so bookmark and service resolutions never collide
+func (res *bookmarkResolution) CacheKey() string {
+	return "@" + res.ChoiceKey()
+}
+
+// Ambiguous reports whether the user should pick among close candidates
+func (res *bookmarkResolution) Ambiguous() bool {
+	return len(res.Choices) > 1
+}
+
+// unifiedResolution is the outcome of ranking services and bookmarks together for a plain query
+type unifiedResolution struct {
+	Services  *serviceResolution
+	Bookmarks []*domain.BookmarkCandidate
+	Matches   []*domain.Match           // ranked services (allowed ones) and bookmarks, best first
+	Bookmark  *domain.BookmarkCandidate // winning bookmark, nil when a service (or nothing) wins
+}
+
+// resolveUnified resolves a plain query against services and bookmarks.
+// Services go through the usual cache, ranking and validation; the best bookmark
+// wins only when its type-weighted score beats the selected service. Queries
+// scoped to a group or carrying a deep-link path only target services.
+func (p *searchPipeline) resolveUnified(ctx context.Context, query, client string, explain bool) *unifiedResolution {
+	res := &unifiedResolution{Services: p.resolveService(ctx, query, client, explain)}
+
+	parsed := res.Services.Parsed
+	if parsed.Raw == "" || parsed.Group != "" || parsed.Path != "" {
+		return res
+	}
+
+	res.Bookmarks = domain.RankBookmarkCandidates(parsed.Raw, p.memIndex.GetAllBookmarks())
+
+	services := make([]*domain.Candidate, 0, len(res.Services.Checks))
+	for _, check := range res.Services.Checks {
+		if check.Allowed {
+			services = append(services, check.Candidate)
+		}
+	}
+	res.Matches = domain.MergeMatches(services, res.Bookmarks)
+
+	// A cached resolution or a recorded pick is what the query led to last time
+	if len(res.Bookmarks) == 0 || res.Services.CacheHit {
+		return res
+	}
+
+	best := domain.BookmarkMatch(res.Bookmarks[0])
+	if res.Services.Service == nil || best.Score > res.Services.Score {
+		res.Bookmark = res.Bookmarks[0]
+	}
+
+	return res
+}
+
+// resolveService runs cache lookup, ranking, allowlist and validation for a query.
+// The client identity (may be empty) personalizes the usage part of the ranking.
+// When explain is true, every candidate in the validation window is checked
+// and ranking runs even on cache hits, so the full breakdown can be reported.
+func (p *searchPipeline) resolveService(ctx context.Context, query, client string, explain bool) *serviceResolution {
+	res := &serviceResolution{
+		Query:  query,
+		Client: client,
+		Parsed: domain.ParseQuery(query),
+	}
+
+	defer p.setTarget(res)
+
+	p.resolveFromCache(ctx, res)
+	if res.CacheHit && !explain {
+		return res
+	}
+
+	// Get all services from memory index
+	services := p.memIndex.GetAllServices()
+	if len(services) == 0 {
+		p.d.Logger.Warn("no services available in index")
+		if !res.CacheHit {
+			res.Reason = ReasonNoServices
+		}
+		return res
+	}
+
+	// Rank candidates
+	candidates := domain.RankCandidatesFor(res.Parsed, services, p.usageProfile(ctx, res.Parsed.Raw, client))
+	if len(candidates) == 0 {
+		if !res.CacheHit {
+			res.Reason = ReasonNoMatch
+		}
+		return res
+	}
+
+	res.Checks = make([]*candidateCheck, len(candidates))
+	for i, candidate := range candidates {
+		res.Checks[i] = &candidateCheck{
+			Candidate: candidate,
+			Rank:      i + 1,
+			Allowed:   isAllowedService(candidate.Service, p.d.AllowedDomains),
+		}
+	}
+
+	// Limit candidates to MaxCandidates (top N only)
+	window := res.Checks
+	if p.d.MaxCandidates > 0 && len(window) > p.d.MaxCandidates {
+		p.d.Logger.Debug("limiting candidates",
+			logger.Int("total", len(window)),
+			logger.Int("max", p.d.MaxCandidates))
+		window = window[:p.d.MaxCandidates]
+	}
+
+	// Drop candidates outside the allowed domains before validating
+	allowed := make([]*candidateCheck, 0, len(window))
+	for _, check := range window {
+		if !check.Allowed {
+			p.d.Logger.Debug("skipping service not in allowed domains",
+				logger.String("hostname", check.Candidate.Service.Hostname))
+			continue
+		}
+		allowed = append(allowed, check)
+	}
+
+	p.validateWindow(ctx, res, allowed, explain)
+
+	if res.Service == nil && !res.CacheHit {
+		res.Reason = ReasonNoHealthyCandidate
+	}
+
+	if !res.CacheHit {
+		p.collectChoices(ctx, res, allowed)
+	}
+
+	return res
+}
+
+// resolveNext resolves a query again while skipping a service reported wrong.
+// Every candidate in the validation window is checked so the runner-up is known.
+func (p *searchPipeline) resolveNext(ctx context.Context, query, client, wrongID string) *serviceResolution {
+	res := p.resolveService(ctx, query, client, true)
+	if res.Service == nil || res.Service.ID != wrongID {
+		return res
+	}
+
+	res.Service = nil
+	res.Reason = ReasonNoHealthyCandidate
+	for _, check := range res.Checks {
+		if check.Checked && check.Err == nil && check.Candidate.Service.ID != wrongID {
+			res.Service = check.Candidate.Service
+			res.Score = check.Candidate.TotalScore
+			res.Reason = ReasonRanked
+			break
+		}
+	}
+	p.setTarget(res)
+
+	return res
+}
+
+// usageProfile loads the past resolutions of the query and the personal usage of the client.
+// Personal usage is left out when personalization is disabled or cannot be loaded.
+func (p *searchPipeline) usageProfile(ctx context.Context, query, client string) *domain.UsageProfile {
+	profile := &domain.UsageProfile{}
+
+	learned, err := p.store.GetLearned(ctx, query)
+	if err != nil {
+		p.d.Logger.Debug("failed to load learned query",
+			logger.Error(err))
+	}
+	profile.Learned = learned
+
+	demoted, err := p.store.GetDemoted(ctx, query)
+	if err != nil {
+		p.d.Logger.Debug("failed to load demoted resolutions",
+			logger.Error(err))
+	}
+	profile.Demoted = demoted
+
+	if client == "" {
+		return profile
+	}
+
+	personal, err := p.store.GetClientUsage(ctx, client)
+	if err != nil {
+		p.d.Logger.Debug("failed to load client usage, using global usage only",
+			logger.Error(err))
+		return profile
+	}
+
+	profile.Personal = personal
+	profile.PersonalWeight = p.d.PersonalUsageWeight
+	profile.GlobalWeight = p.d.GlobalUsageWeight
+	return profile
+}
+
+// collectChoices gathers the candidates whose score is within the chooser margin of the selected one.
+// Validation stops at the winner, so the contenders it did not reach are validated first:
+// only candidates that passed validation are offered.
+func (p *searchPipeline) collectChoices(ctx context.Context, res *serviceResolution, checks []*candidateCheck) {
+	if p.d.ChooserMargin <= 0 || res.Service == nil {
+		return
+	}
+
+	var selected *candidateCheck
+	for _, check := range checks {
+		if check.Candidate.Service == res.Service {
+			selected = check
+			break
+		}
+	}
+	if selected == nil {
+		return
+	}
+
+	var contenders, unchecked []*candidateCheck
+	for _, check := range checks {
+		if check.Rank <= selected.Rank || check.Candidate.Service.Hidden {
+			continue
+		}
+		if selected.Candidate.TotalScore-check.Candidate.TotalScore > p.d.ChooserMargin {
+			break
+		}
+		contenders = append(contenders, check)
+		if !check.Checked {
+			unchecked = append(unchecked, check)
+		}
+	}
+
+	if len(unchecked) > 0 {
+		candidates := make([]*domain.Candidate, len(unchecked))
+		for i, check := range unchecked {
+			candidates[i] = check.Candidate
+		}
+		errs := domain.ValidateAll(ctx, candidates, p.validator())
+		for i, check := range unchecked {
+			p.markChecked(check, errs[i])
+		}
+	}
+
+	choices := []*candidateCheck{selected}
+	for _, check := range contenders {
+		if !check.Checked || check.Err != nil {
+			continue
+		}
+		choices = append(choices, check)
+	}
+
+	if len(choices) > 1 {
+		res.Choices = choices
+		res.Reason = ReasonAmbiguous
+	}
+}
+
+// validateWindow validates the candidates concurrently and selects the best-ranked healthy one.
+// Outside explain mode, validations stop as soon as the winner is known.
+func (p *searchPipeline) validateWindow(ctx context.Context, res *serviceResolution, checks []*candidateCheck, explain bool) {
+	candidates := make([]*domain.Candidate, len(checks))
+	for i, check := range checks {
+		candidates[i] = check.Candidate
+	}
+
+	var errs []error
+	if explain {
+		errs = domain.ValidateAll(ctx, candidates, p.validator())
+	} else {
+		var winner *domain.Candidate
+		winner, errs = domain.ValidateMultiple(ctx, candidates, p.validator())
+		if winner != nil {
+			// Candidates ranked below the winner were cancelled: they stay unchecked
+			for i := range checks {
+				if checks[i].Candidate == winner {
+					checks = checks[:i+1]
+					break
+				}
+			}
+		}
+	}
+
+	for i, check := range checks {
+		p.markChecked(check, errs[i])
+		if check.Err != nil {
+			p.d.Logger.Debug("service validation failed",
+				logger.String("hostname", check.Candidate.Service.Hostname),
+				logger.Error(check.Err))
+			continue
+		}
+
+		if res.Service == nil && !res.CacheHit {
+			res.Service = check.Candidate.Service
+			res.Score = check.Candidate.TotalScore
+			res.Reason = ReasonRanked
+		}
+	}
+}
+
+// resolveFromCache fills the resolution from a recorded pick or a cached query resolution, if still valid
+func (p *searchPipeline) resolveFromCache(ctx context.Context, res *serviceResolution) {
+	if res.CacheKey() == "" {
+		return
+	}
+
+	// A pick recorded from the chooser takes precedence over the resolution cache
+	if choiceID, err := p.store.GetChoice(ctx, redisstore.ChoiceRealmService, res.CacheKey()); err == nil && choiceID != "" {
+		if service := p.cachedService(ctx, choiceID); service != nil {
+			res.CacheHit = true
+			res.Choice = true
+			res.CachedID = choiceID
+			res.Service = service
+			res.Reason = ReasonChoice
+			return
+		}
+	}
+
+	cachedID, err := p.store.GetCachedResolution(ctx, res.CacheKey())
+	if err != nil || cachedID == "" {
+		return
+	}
+	res.CachedID = cachedID
+
+	service := p.cachedService(ctx, cachedID)
+	if service == nil {
+		res.CacheStale = true
+		return
+	}
+
+	res.CacheHit = true
+	res.Service = service
+	res.Reason = ReasonCacheHit
+}
+
+// cachedService returns a cached service if it still exists, is allowed and passes validation
+func (p *searchPipeline) cachedService(ctx context.Context, id string) *domain.Service {
+	service, ok := p.memIndex.GetService(id)
+	if !ok || service.Disabled {
+		return nil
+	}
+
+	check := &candidateCheck{
+		Candidate: &domain.Candidate{Service: service},
+		Allowed:   isAllowedService(service, p.d.AllowedDomains),
+	}
+	if !check.Allowed {
+		p.d.Logger.Warn("cached hostname not in allowed domains",
+			logger.String("hostname", service.Hostname))
+		return nil
+	}
+
+	// Validate cached service is still alive
+	p.markChecked(check, p.validator()(ctx, check.Candidate))
+	if check.Err != nil {
+		p.d.Logger.Debug("cached service is down, ignoring cache",
+			logger.String("hostname", service.Hostname))
+		return nil
+	}
+
+	return service
+}
+
+// setTarget builds the redirect URL of the selected service, appending the deep-link path
+func (p *searchPipeline) setTarget(res *serviceResolution) {
+	if res.Service == nil {
+		return
+	}
+	res.TargetURL, res.PathErr = p.serviceTarget(res.Service, res.Parsed.Path)
+}
+
+// serviceTarget builds the redirect URL of a service with a deep-link path.
+// An unsafe path is reported and the default target is returned instead.
+func (p *searchPipeline) serviceTarget(service *domain.Service, path string) (string, error) {
+	target, err := service.TargetURL(path)
+	if err != nil {
+		p.d.Logger.Warn("rejected deep-link path",
+			logger.String("hostname", service.Hostname),
+			logger.String("path", path),
+			logger.Error(err))
+		target, _ = service.TargetURL("")
+	}
+	return target, err
+}
+
+// validator returns the candidate validator configured for this instance.
+// Fresh background probe results are trusted; missing or stale ones fall back to a live check.
+func (p *searchPipeline) validator() domain.CandidateValidator {
+	if p.d.SkipTLSValidation {
+		return func(context.Context, *domain.Candidate) error { return nil }
+	}
+
+	live := domain.TLSValidator(p.d.TLSTimeout)
+	return func(ctx context.Context, candidate *domain.Candidate) error {
+		if h, ok := p.freshHealth(candidate.Service.ID); ok {
+			if h.Up {
+				return nil
+			}
+			return fmt.Errorf("service down at last probe: %s", h.LastError)
+		}
+		return live(ctx, candidate)
+	}
+}
+
+// freshHealth returns the probe result of a service if it is recent enough to be trusted
+func (p *searchPipeline) freshHealth(id string) (*domain.Health, bool) {
+	h, ok := p.memIndex.GetHealth(id)
+	if !ok || !h.IsFresh(time.Now(), p.d.HealthStaleAfter) {
+		return nil, false
+	}
+	return h, true
+}
+
+// markChecked records a validation outcome on a candidate check
+func (p *searchPipeline) markChecked(check *candidateCheck, err error) {
+	check.Checked = true
+	check.Err = err
+	if p.d.SkipTLSValidation {
+		p.d.Logger.Debug("skipping TLS validation (disabled in config)",
+			logger.String("hostname", check.Candidate.Service.Hostname),
+			logger.String("score", fmt.Sprintf("%.2f", check.Candidate.TotalScore)),
+			logger.Int("rank", check.Rank))
+		check.Skipped = true
+		return
+	}
+	_, check.Probed = p.freshHealth(check.Candidate.Service.ID)
+}
+
+// resolveBookmark ranks bookmarks for a query (without the @ prefix).
+// The client identity (may be empty) scopes recorded picks and cached resolutions.
+// When explain is true, ranking runs even on cache hits so the candidates can be reported.
+func (p *searchPipeline) resolveBookmark(ctx context.Context, query, client string, explain bool) *bookmarkResolution {
+	res := &bookmarkResolution{Query: strings.TrimSpace(query), Client: client}
+
+	if res.Query == "" {
+		res.Reason = ReasonEmptyQuery
+		return res
+	}
+
+	// A pick recorded from the chooser or a cached resolution wins over ranking
+	p.resolveBookmarkFromCache(ctx, res)
+	if res.CacheHit && !explain {
+		return res
+	}
+
+	// Get all bookmarks from memory index
+	bookmarks := p.memIndex.GetAllBookmarks()
+	if len(bookmarks) == 0 {
+		p.d.Logger.Warn("no bookmarks available in index")
+		if !res.CacheHit {
+			res.Reason = ReasonNoBookmarks
+		}
+		return res
+	}
+
+	// Rank bookmark candidates
+	res.Candidates = domain.RankBookmarkCandidates(res.Query, bookmarks)
+	if res.CacheHit {
+		return res
+	}
+	if len(res.Candidates) == 0 {
+		res.Reason = ReasonNoMatch
+		return res
+	}
+
+	// Best bookmark wins (no TLS validation for external URLs), usage breaks ties
+	res.Bookmark = res.Candidates[0].Bookmark
+	res.Score = res.Candidates[0].Score
+	res.Reason = ReasonRanked
+
+	// Offer a chooser when other bookmarks score within the margin
+	if p.d.ChooserMargin > 0 {
+		for i, candidate := range res.Candidates {
+			if res.Score-candidate.Score > p.d.ChooserMargin {
+				break
+			}
+			res.Choices = res.Candidates[:i+1]
+		}
+		if res.Ambiguous() {
+			res.Reason = ReasonAmbiguous
+		} else {
+			res.Choices = nil
+		}
+	}
+
+	return res
+}
+
+// resolveBookmarkFromCache fills the resolution from a recorded pick or a cached query resolution, if still active
+func (p *searchPipeline) resolveBookmarkFromCache(ctx context.Context, res *bookmarkResolution) {
+	if choiceID, err := p.store.GetChoice(ctx, redisstore.ChoiceRealmBookmark, res.ChoiceKey()); err == nil && choiceID != "" {
+		if bookmark, ok := p.activeBookmark(choiceID); ok {
+			res.CacheHit = true
+			res.Choice = true
+			res.CachedID = choiceID
+			res.Bookmark = bookmark
+			res.Reason = ReasonChoice
+			return
+		}
+	}
+
+	cachedID, err := p.store.GetCachedResolution(ctx, res.CacheKey())
+	if err != nil || cachedID == "" {
+		return
+	}
+	res.CachedID = cachedID
+
+	bookmark, ok := p.activeBookmark(cachedID)
+	if !ok {
+		res.CacheStale = true
+		return
+	}
+
+	res.CacheHit = true
+	res.Bookmark = bookmark
+	res.Reason = ReasonCacheHit
+}
+
+// activeBookmark returns a bookmark if it still exists and is not disabled
+func (p *searchPipeline) activeBookmark(id string) (*domain.Bookmark, bool) {
+	bookmark, ok := p.memIndex.GetBookmark(id)
+	if !ok || bookmark.Disabled {
+		return nil, false
+	}
+	return bookmark, true
+}
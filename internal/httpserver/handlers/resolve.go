@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+// MaxResolveBatch is the maximum number of queries accepted by a batch resolve
+const MaxResolveBatch = 20
+
+type resolveTarget struct {
+	Kind string `json:"kind"` // service, bookmark, search or internal
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url"`
+}
+
+type resolveResult struct {
+	Query   string          `json:"query"`
+	Realm   string          `json:"realm"`
+	Found   bool            `json:"found"`
+	URL     string          `json:"url,omitempty"` // target when found, where a browser would be sent otherwise
+	Match   *resolveTarget  `json:"match,omitempty"`
+	Score   float64         `json:"score,omitempty"`
+	Reason  string          `json:"reason"`
+	Choices []resolveTarget `json:"choices,omitempty"` // close contenders a browser would be asked to pick from
+}
+
+type resolveBatchRequest struct {
+	Queries []string `json:"queries"`
+}
+
+type resolveBatchResponse struct {
+	Results []*resolveResult `json:"results"`
+}
+
+// Resolve resolves a single query (GET ?q=) and returns the outcome as JSON.
+// It runs the same pipeline as Search (cache, ranking, allowlist, validation)
+// but never redirects and has no side effects: no usage counters, no cache writes.
+func Resolve(d deps.Deps) http.HandlerFunc {
+	pipeline := newSearchPipeline(d)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := strings.TrimSpace(r.URL.Query().Get("q"))
+		client := existingClientIdentity(r, d)
+
+		writeJSON(w, http.StatusOK, resolveQuery(r.Context(), query, client, pipeline, d), d)
+	}
+}
+
+// ResolveBatch resolves several queries at once (POST {"queries": [...]}).
+// Results are returned in the order of the queries.
+func ResolveBatch(d deps.Deps) http.HandlerFunc {
+	pipeline := newSearchPipeline(d)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !requireJSON(w, r, d) {
+			return
+		}
+
+		var request resolveBatchRequest
+		if err := decodeJSON(w, r, &request); err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid request body", d)
+			return
+		}
+		if len(request.Queries) > MaxResolveBatch {
+			writeAPIError(w, http.StatusBadRequest, "too many queries", d)
+			return
+		}
+
+		client := existingClientIdentity(r, d)
+
+		// Queries are independent: validate them in parallel
+		response := resolveBatchResponse{Results: make([]*resolveResult, len(request.Queries))}
+		var wg sync.WaitGroup
+		for i, query := range request.Queries {
+			wg.Add(1)
+			go func(i int, query string) {
+				defer wg.Done()
+				response.Results[i] = resolveQuery(r.Context(), strings.TrimSpace(query), client, pipeline, d)
+			}(i, query)
+		}
+		wg.Wait()
+
+		d.Logger.Debug("batch resolve",
+			logger.Int("queries", len(request.Queries)))
+
+		writeJSON(w, http.StatusOK, response, d)
+	}
+}
+
+// resolveQuery routes a query to its realm, like Search, and describes the outcome
+func resolveQuery(ctx context.Context, query, client string, p *searchPipeline, d deps.Deps) *resolveResult {
+	result := &resolveResult{Query: query}
+	engine, bangTarget, isBang := bangURL(query, d)
+
+	switch {
+	case query == "":
+		result.Realm = "none"
+		result.Reason = ReasonEmptyQuery
+		result.URL = d.HomepageURL
+	case isWrongFeedback(query):
+		// Reporting a wrong result has side effects: use POST /feedback
+		result.Realm = "feedback"
+		result.Reason = ReasonFeedback
+	case isBang:
+		result.Realm = "bang"
+		result.Reason = ReasonRanked
+		result.found(resolveTarget{Kind: "search", ID: engine, URL: bangTarget})
+	case strings.HasPrefix(query, "@"):
+		resolveBookmarkResult(result, p.resolveBookmark(ctx, strings.TrimPrefix(query, "@"), client, false), d)
+	case strings.HasPrefix(query, domain.ShortcutPrefix):
+		resolveShortcutResult(result, resolveShortcut(query, d.Shortcuts), d)
+	case d.UnifiedSearch:
+		res := p.resolveUnified(ctx, query, client, false)
+		if res.Bookmark == nil {
+			resolveServiceResult(result, res.Services, d)
+			break
+		}
+		result.Realm = "unified"
+		result.Reason = ReasonRanked
+		result.Score = res.Bookmark.Score
+		result.found(resolvedBookmark(res.Bookmark.Bookmark))
+	default:
+		resolveServiceResult(result, p.resolveService(ctx, query, client, false), d)
+	}
+
+	return result
+}
+
+// found records the match of a result and its target URL
+func (res *resolveResult) found(target resolveTarget) {
+	res.Found = true
+	res.Match = &target
+	res.URL = target.URL
+}
+
+// resolveServiceResult describes a service resolution
+func resolveServiceResult(result *resolveResult, res *serviceResolution, d deps.Deps) {
+	result.Realm = "services"
+	if d.UnifiedSearch {
+		result.Realm = "unified"
+	}
+	result.Reason = res.Reason
+
+	if res.Service == nil {
+		result.URL = fallbackURL(result.Query, d)
+		return
+	}
+
+	result.Score = res.Score
+	result.found(resolvedService(res.Service, res.TargetURL))
+
+	if res.Ambiguous() {
+		for _, check := range res.Choices {
+			service := check.Candidate.Service
+			target, _ := service.TargetURL(res.Parsed.Path)
+			result.Choices = append(result.Choices, resolvedService(service, target))
+		}
+	}
+}
+
+// resolveBookmarkResult describes a bookmark resolution
+func resolveBookmarkResult(result *resolveResult, res *bookmarkResolution, d deps.Deps) {
+	result.Realm = "bookmarks"
+	result.Reason = res.Reason
+
+	if res.Bookmark == nil {
+		result.URL = fallbackURL(res.Query, d)
+		return
+	}
+
+	result.Score = res.Score
+	result.found(resolvedBookmark(res.Bookmark))
+
+	if res.Ambiguous() {
+		for _, candidate := range res.Choices {
+			result.Choices = append(result.Choices, resolvedBookmark(candidate.Bookmark))
+		}
+	}
+}
+
+// resolveShortcutResult describes a shortcut resolution.
+// Shortcut URLs are paths, relative to the Jump instance.
+func resolveShortcutResult(result *resolveResult, res *shortcutResolution, d deps.Deps) {
+	result.Realm = "internal"
+	result.Reason = res.Reason
+
+	switch {
+	case strings.TrimSpace(result.Query) == domain.ShortcutPrefix:
+		// "/" alone lists the shortcuts
+		result.Reason = ReasonAmbiguous
+		for _, shortcut := range d.Shortcuts {
+			if shortcut.Safe {
+				result.Choices = append(result.Choices, resolvedShortcut(shortcut))
+			}
+		}
+	case res.Shortcut != nil:
+		result.Score = res.Candidates[0].Score
+		result.found(resolvedShortcut(*res.Shortcut))
+	case len(res.Choices) > 0:
+		for _, shortcut := range res.Choices {
+			result.Choices = append(result.Choices, resolvedShortcut(shortcut))
+		}
+	default:
+		result.URL = d.HomepageURL
+	}
+}
+
+func resolvedService(service *domain.Service, url string) resolveTarget {
+	name := service.DisplayName
+	if name == "" {
+		name = service.Name
+	}
+	return resolveTarget{Kind: "service", ID: service.ID, Name: name, URL: url}
+}
+
+func resolvedBookmark(bookmark *domain.Bookmark) resolveTarget {
+	name := bookmark.Name
+	if name == "" {
+		name = bookmark.Abbr
+	}
+	return resolveTarget{Kind: "bookmark", ID: bookmark.ID, Name: name, URL: bookmark.URL}
+}
+
+func resolvedShortcut(shortcut domain.Shortcut) resolveTarget {
+	return resolveTarget{Kind: "internal", ID: shortcut.Name, Name: shortcut.Description, URL: shortcut.Path}
+}
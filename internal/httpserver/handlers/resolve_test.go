@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// decodeResponse decodes a JSON response body
+func decodeResponse(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("invalid response %s: %v", w.Body.String(), err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	d := newTestDeps(t, testServices()...)
+	d.ClientIdentity = clientIdentityCookie
+	d.ClientCookie = "jump_client"
+	handler := Resolve(d)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/resolve?q=jellyfin", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var result resolveResult
+	decodeResponse(t, w, &result)
+	if !result.Found || result.Realm != "services" || result.Match == nil || result.Match.ID != "jellyfin.domain.ext" {
+		t.Fatalf("result = %+v, want jellyfin", result)
+	}
+	if result.URL != "https://jellyfin.domain.ext" {
+		t.Errorf("url = %q", result.URL)
+	}
+
+	// Resolving has no side effects: no usage, no client ID cookie
+	if cookies := w.Result().Cookies(); len(cookies) != 0 {
+		t.Errorf("cookies = %v, want none", cookies)
+	}
+	if service, _ := d.MemoryIndex.GetService("jellyfin.domain.ext"); service.Counter != 0 {
+		t.Errorf("counter = %d, want 0", service.Counter)
+	}
+}
+
+func TestResolve_NotFound(t *testing.T) {
+	d := newTestDeps(t, testServices()...)
+
+	w := httptest.NewRecorder()
+	Resolve(d).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/resolve?q=zzzz", nil))
+
+	var result resolveResult
+	decodeResponse(t, w, &result)
+	if result.Found || result.Reason != ReasonNoMatch || result.URL != d.HomepageURL {
+		t.Errorf("result = %+v, want a miss sent to the homepage", result)
+	}
+}
+
+func TestResolveBatch(t *testing.T) {
+	handler := ResolveBatch(newTestDeps(t, testServices()...))
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/resolve", strings.NewReader(`{"queries": ["graf", "zzzz", "jellyfin"]}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var response resolveBatchResponse
+	decodeResponse(t, w, &response)
+	if len(response.Results) != 3 {
+		t.Fatalf("results = %d, want 3", len(response.Results))
+	}
+
+	// Results keep the order of the queries
+	want := []string{"grafana.domain.ext", "", "jellyfin.domain.ext"}
+	for i, result := range response.Results {
+		id := ""
+		if result.Match != nil {
+			id = result.Match.ID
+		}
+		if id != want[i] {
+			t.Errorf("result %d = %q, want %q", i, id, want[i])
+		}
+	}
+}
+
+func TestResolveBatch_InvalidRequests(t *testing.T) {
+	handler := ResolveBatch(newTestDeps(t, testServices()...))
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"form content type", "application/x-www-form-urlencoded", `{"queries": ["graf"]}`, http.StatusUnsupportedMediaType},
+		{"text content type", "text/plain", `{"queries": ["graf"]}`, http.StatusUnsupportedMediaType},
+		{"malformed body", "application/json", `{"queries": `, http.StatusBadRequest},
+		{"unknown field", "application/json", `{"query": "graf"}`, http.StatusBadRequest},
+		{"too many queries", "application/json", `{"queries": [` + strings.Repeat(`"a",`, MaxResolveBatch) + `"a"]}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/resolve", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			var response apiError
+			decodeResponse(t, w, &response)
+			if response.Error == "" {
+				t.Error("error message is empty")
+			}
+		})
+	}
+}
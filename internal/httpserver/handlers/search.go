@@ -4,23 +4,27 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/MrSnakeDoc/jump/internal/domain"
 	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
-	"github.com/MrSnakeDoc/jump/internal/index"
 	"github.com/MrSnakeDoc/jump/internal/logger"
 	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
 )
 
 func Search(d deps.Deps) http.HandlerFunc {
-	store := redisstore.NewStore(d.RedisClient)
-	memIndex := d.MemoryIndex
+	pipeline := newSearchPipeline(d)
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
 		query := strings.TrimSpace(r.URL.Query().Get("q"))
 
+		// Explain mode: report the ranking breakdown instead of redirecting
+		if wantsExplain(r) {
+			writeExplain(w, r, query, pipeline, d)
+			return
+		}
+
 		// Empty query -> redirect to homepage
 		if query == "" {
 			d.Logger.Debug("empty query, redirecting to homepage")
@@ -31,251 +35,224 @@ func Search(d deps.Deps) http.HandlerFunc {
 		d.Logger.Info("search request",
 			logger.String("query", query))
 
+		// Special case: "!wrong" asks to confirm reporting the last redirect
+		if isWrongFeedback(query) {
+			handleWrongFeedback(w, d)
+			return
+		}
+
+		// Special case: bangs (queries like "!gh jump" forwarded to a search engine)
+		if engine, target, ok := bangURL(query, d); ok {
+			d.Logger.Info("bang redirect",
+				logger.String("query", query),
+				logger.String("engine", engine))
+			http.Redirect(w, r, target, http.StatusFound)
+			return
+		}
+
 		// Special case: bookmarks (queries starting with @)
 		if strings.HasPrefix(query, "@") {
-			handleBookmarkSearch(w, r, query, d, memIndex)
+			handleBookmarkSearch(w, r, query, pipeline, d)
 			return
 		}
 
 		// Special case: internal endpoints (queries starting with /)
-		if strings.HasPrefix(query, "/") {
+		if strings.HasPrefix(query, domain.ShortcutPrefix) {
 			handleInternalEndpoint(w, r, query, d)
 			return
 		}
 
-		// Try cache first
-		if handleCachedService(w, r, ctx, query, store, memIndex, d) {
+		// Unified mode: services and bookmarks ranked together
+		if d.UnifiedSearch {
+			handleUnifiedSearch(w, r, query, pipeline, d)
 			return
 		}
 
-		// Search and validate services
-		handleServiceSearch(w, r, ctx, query, store, memIndex, d)
+		// Cache, search and validate services
+		handleServiceSearch(w, r, query, pipeline, d)
 	}
 }
 
-// handleInternalEndpoint handles internal endpoint routing
-func handleInternalEndpoint(w http.ResponseWriter, r *http.Request, query string, d deps.Deps) {
-	if endpoint := matchInternalEndpoint(query); endpoint != "" {
-		internalURL := fmt.Sprintf("https://%s%s", r.Host, endpoint)
-		d.Logger.Info("internal endpoint redirect",
-			logger.String("query", query),
-			logger.String("endpoint", endpoint))
-		http.Redirect(w, r, internalURL, http.StatusFound)
-		return
-	}
-	// No match found, redirect to homepage
-	d.Logger.Debug("no internal endpoint matched",
-		logger.String("query", query))
-	http.Redirect(w, r, d.HomepageURL, http.StatusFound)
+// handleServiceSearch resolves a service query, records usage and redirects
+func handleServiceSearch(w http.ResponseWriter, r *http.Request, query string, p *searchPipeline, d deps.Deps) {
+	client := clientIdentity(w, r, d)
+	redirectService(w, r, query, client, p.resolveService(r.Context(), query, client, false), p, d)
 }
 
-// handleCachedService checks cache and redirects if valid, returns true if handled
-func handleCachedService(w http.ResponseWriter, r *http.Request, ctx context.Context, query string, store *redisstore.Store, memIndex *index.MemoryIndex, d deps.Deps) bool {
-	cachedHostname, err := store.GetCachedResolution(ctx, query)
-	if err != nil || cachedHostname == "" {
-		return false
-	}
+// handleUnifiedSearch resolves a plain query against services and bookmarks and redirects to the winner
+func handleUnifiedSearch(w http.ResponseWriter, r *http.Request, query string, p *searchPipeline, d deps.Deps) {
+	client := clientIdentity(w, r, d)
+	res := p.resolveUnified(r.Context(), query, client, false)
 
-	// Validate cached service is still alive
-	if err := domain.ValidateTLS(cachedHostname, d.TLSTimeout); err == nil {
-		d.Logger.Info("cache hit, redirecting",
-			logger.String("query", query),
-			logger.String("hostname", cachedHostname))
+	if res.Bookmark == nil {
+		redirectService(w, r, query, client, res.Services, p, d)
+		return
+	}
 
-		// Increment usage counter (best effort)
-		_ = store.IncrementUsage(ctx, cachedHostname)
-		memIndex.IncrementCounter(cachedHostname)
+	bookmark := res.Bookmark.Bookmark
+	d.Logger.Info("resolved bookmark in unified search",
+		logger.String("query", query),
+		logger.String("abbr", bookmark.Abbr),
+		logger.String("url", bookmark.URL),
+		logger.String("score", fmt.Sprintf("%.2f", res.Bookmark.Score)))
 
-		redirectURL := fmt.Sprintf("https://%s", cachedHostname)
-		if !isAllowedRedirect(cachedHostname, d.AllowedDomains) {
-			d.Logger.Warn("cached hostname not in allowed domains",
-				logger.String("hostname", cachedHostname))
-			http.Redirect(w, r, d.HomepageURL, http.StatusFound)
-			return true
-		}
-
-		http.Redirect(w, r, redirectURL, http.StatusFound)
-		return true
-	}
+	recordBookmarkUsage(r.Context(), p, bookmark.ID)
 
-	// Cache hit but service is down, invalidate cache
-	d.Logger.Debug("cached service is down, invalidating cache",
-		logger.String("hostname", cachedHostname))
-	_ = store.InvalidateCache(ctx, query)
-	return false
+	http.Redirect(w, r, bookmark.URL, http.StatusFound)
 }
 
-// handleServiceSearch searches, validates and redirects to a service
-func handleServiceSearch(w http.ResponseWriter, r *http.Request, ctx context.Context, query string, store *redisstore.Store, memIndex *index.MemoryIndex, d deps.Deps) {
-	// Parse query
-	parsedQuery := domain.ParseQuery(query)
+// redirectService commits a service resolution: fallback, chooser, or usage, cache and redirect
+func redirectService(w http.ResponseWriter, r *http.Request, query, client string, res *serviceResolution, p *searchPipeline, d deps.Deps) {
+	ctx := r.Context()
 
-	// Get all services from memory index
-	services := memIndex.GetAllServices()
-	if len(services) == 0 {
-		d.Logger.Warn("no services available in index")
-		http.Redirect(w, r, d.HomepageURL, http.StatusFound)
-		return
+	// Cache hit but service is gone or down, invalidate cache
+	if res.CacheStale {
+		_ = p.store.InvalidateCache(ctx, res.CacheKey())
 	}
 
-	// Rank candidates
-	candidates := domain.RankCandidates(parsedQuery, services)
-	if len(candidates) == 0 {
-		d.Logger.Info("no matching services found",
-			logger.String("query", query))
-		http.Redirect(w, r, d.HomepageURL, http.StatusFound)
+	if res.Service == nil {
+		switch res.Reason {
+		case ReasonNoMatch:
+			d.Logger.Info("no matching services found",
+				logger.String("query", query))
+		case ReasonNoHealthyCandidate:
+			d.Logger.Warn("no healthy service found for query",
+				logger.String("query", query))
+		}
+		http.Redirect(w, r, fallbackURL(query, d), http.StatusFound)
 		return
 	}
 
-	// Limit candidates to MaxCandidates (top N only)
-	if d.MaxCandidates > 0 && len(candidates) > d.MaxCandidates {
-		d.Logger.Debug("limiting candidates",
-			logger.Int("total", len(candidates)),
-			logger.Int("max", d.MaxCandidates))
-		candidates = candidates[:d.MaxCandidates]
+	// Close candidates: let the user pick instead of guessing
+	if res.Ambiguous() {
+		d.Logger.Info("ambiguous query, rendering chooser",
+			logger.String("query", query),
+			logger.Int("choices", len(res.Choices)))
+		renderChooser(w, serviceChooser(query, res), d)
+		return
 	}
 
-	// Validate candidates in order and redirect to first healthy one
-	for i, candidate := range candidates {
-		hostname := candidate.Service.Hostname
-
-		// Check if redirect is allowed
-		if !isAllowedRedirect(hostname, d.AllowedDomains) {
-			d.Logger.Debug("skipping service not in allowed domains",
-				logger.String("hostname", hostname))
-			continue
-		}
-
-		// Skip TLS validation if configured
-		if !d.SkipTLSValidation {
-			// Validate TLS
-			if err := domain.ValidateTLS(hostname, d.TLSTimeout); err != nil {
-				d.Logger.Debug("service validation failed",
-					logger.String("hostname", hostname),
-					logger.Error(err))
-				continue
-			}
-		} else {
-			d.Logger.Debug("skipping TLS validation (disabled in config)",
-				logger.String("hostname", hostname),
-				logger.String("score", fmt.Sprintf("%.2f", candidate.TotalScore)),
-				logger.Int("rank", i+1))
-		}
-
-		// Found a healthy service!
+	service := res.Service
+	if res.Choice {
+		d.Logger.Info("recorded choice, redirecting",
+			logger.String("query", query),
+			logger.String("hostname", service.Hostname))
+	} else if res.CacheHit {
+		d.Logger.Info("cache hit, redirecting",
+			logger.String("query", query),
+			logger.String("hostname", service.Hostname))
+	} else {
 		d.Logger.Info("resolved and validated service",
 			logger.String("query", query),
-			logger.String("hostname", hostname),
-			logger.String("score", fmt.Sprintf("%.2f", candidate.TotalScore)))
-
-		// Increment usage counter (best effort)
-		_ = store.IncrementUsage(ctx, hostname)
-		memIndex.IncrementCounter(hostname)
+			logger.String("hostname", service.Hostname),
+			logger.String("score", fmt.Sprintf("%.2f", res.Score)))
+	}
 
-		// Cache the resolution
-		_ = store.CacheResolution(ctx, query, hostname, redisstore.DefaultCacheTTL)
+	recordUsage(ctx, p, res.Parsed.Raw, client, service.ID)
+	rememberRedirect(ctx, r, client, res, p, d)
 
-		// Redirect
-		redirectURL := fmt.Sprintf("https://%s", hostname)
-		http.Redirect(w, r, redirectURL, http.StatusFound)
-		return
+	// Cache the resolution
+	if !res.CacheHit {
+		_ = p.store.CacheResolution(ctx, res.CacheKey(), service.ID, redisstore.DefaultCacheTTL)
 	}
 
-	// No healthy service found
-	d.Logger.Warn("no healthy service found for query",
-		logger.String("query", query))
-	http.Redirect(w, r, d.HomepageURL, http.StatusFound)
+	// Redirect
+	http.Redirect(w, r, res.TargetURL, http.StatusFound)
 }
 
 // handleBookmarkSearch handles bookmark searches (queries starting with @)
-func handleBookmarkSearch(w http.ResponseWriter, r *http.Request, query string, d deps.Deps, memIndex *index.MemoryIndex) {
-	// Remove @ prefix
-	queryStr := strings.TrimPrefix(query, "@")
-	queryStr = strings.TrimSpace(queryStr)
-
-	// Empty query after @ -> redirect to homepage
-	if queryStr == "" {
-		d.Logger.Debug("empty bookmark query, redirecting to homepage")
-		http.Redirect(w, r, d.HomepageURL, http.StatusFound)
-		return
+func handleBookmarkSearch(w http.ResponseWriter, r *http.Request, query string, p *searchPipeline, d deps.Deps) {
+	ctx := r.Context()
+	res := p.resolveBookmark(ctx, strings.TrimPrefix(query, "@"), clientIdentity(w, r, d), false)
+
+	// Cached bookmark is gone or disabled, invalidate cache
+	if res.CacheStale {
+		_ = p.store.InvalidateCache(ctx, res.CacheKey())
 	}
 
-	// Get all bookmarks from memory index
-	bookmarks := memIndex.GetAllBookmarks()
-	if len(bookmarks) == 0 {
-		d.Logger.Warn("no bookmarks available in index")
-		http.Redirect(w, r, d.HomepageURL, http.StatusFound)
+	if res.Bookmark == nil {
+		switch res.Reason {
+		case ReasonEmptyQuery:
+			d.Logger.Debug("empty bookmark query, redirecting to homepage")
+		case ReasonNoMatch:
+			d.Logger.Info("no matching bookmarks found",
+				logger.String("query", res.Query))
+		}
+		http.Redirect(w, r, fallbackURL(res.Query, d), http.StatusFound)
 		return
 	}
 
-	// Rank bookmark candidates
-	candidates := domain.RankBookmarkCandidates(queryStr, bookmarks)
-	if len(candidates) == 0 {
-		d.Logger.Info("no matching bookmarks found",
-			logger.String("query", queryStr))
-		http.Redirect(w, r, d.HomepageURL, http.StatusFound)
+	// Close candidates: let the user pick instead of guessing
+	if res.Ambiguous() {
+		d.Logger.Info("ambiguous bookmark query, rendering chooser",
+			logger.String("query", res.Query),
+			logger.Int("choices", len(res.Choices)))
+		renderChooser(w, bookmarkChooser(query, res), d)
 		return
 	}
 
-	// Get best bookmark (no TLS validation for external URLs)
-	bestBookmark := candidates[0].Bookmark
+	if res.Choice {
+		d.Logger.Info("recorded bookmark choice, redirecting",
+			logger.String("query", res.Query),
+			logger.String("abbr", res.Bookmark.Abbr))
+	} else if res.CacheHit {
+		d.Logger.Info("bookmark cache hit, redirecting",
+			logger.String("query", res.Query),
+			logger.String("abbr", res.Bookmark.Abbr))
+	} else {
+		d.Logger.Info("resolved bookmark",
+			logger.String("query", res.Query),
+			logger.String("abbr", res.Bookmark.Abbr),
+			logger.String("url", res.Bookmark.URL),
+			logger.String("score", fmt.Sprintf("%.2f", res.Score)))
+	}
+
+	recordBookmarkUsage(ctx, p, res.Bookmark.ID)
 
-	d.Logger.Info("resolved bookmark",
-		logger.String("query", queryStr),
-		logger.String("abbr", bestBookmark.Abbr),
-		logger.String("url", bestBookmark.URL),
-		logger.String("score", fmt.Sprintf("%.2f", candidates[0].Score)))
+	// Cache the resolution
+	if !res.CacheHit {
+		_ = p.store.CacheResolution(ctx, res.CacheKey(), res.Bookmark.ID, redisstore.DefaultCacheTTL)
+	}
 
 	// Redirect to bookmark URL
-	http.Redirect(w, r, bestBookmark.URL, http.StatusFound)
+	http.Redirect(w, r, res.Bookmark.URL, http.StatusFound)
 }
 
-// isAllowedRedirect checks if a hostname is allowed for redirection
-func isAllowedRedirect(hostname string, allowedDomains []string) bool {
-	hostname = strings.ToLower(hostname)
-
-	for _, domain := range allowedDomains {
-		domain = strings.ToLower(domain)
-
-		// Exact match
-		if hostname == domain {
-			return true
-		}
+// recordUsage increments the global and personal usage counters of a service
+// and learns that the normalized query led to it (best effort)
+func recordUsage(ctx context.Context, p *searchPipeline, query, client, serviceID string) {
+	_ = p.store.IncrementUsage(ctx, serviceID)
+	p.memIndex.IncrementCounter(serviceID)
 
-		// Subdomain match (hostname ends with .domain)
-		if strings.HasSuffix(hostname, "."+domain) {
-			return true
-		}
+	if query != "" {
+		_ = p.store.RecordLearned(ctx, query, serviceID)
 	}
 
-	return false
-}
-
-// matchInternalEndpoint performs fuzzy matching on internal endpoints
-// Returns the full endpoint path if a unique match is found, empty string otherwise
-func matchInternalEndpoint(query string) string {
-	// Available internal endpoints
-	endpoints := []string{
-		"/infra",
-		"/healthz",
-		"/readyz",
+	if client != "" {
+		_ = p.store.IncrementClientUsage(ctx, client, serviceID)
 	}
+}
 
-	query = strings.ToLower(query)
-	var matches []string
+// recordBookmarkUsage increments the usage counter of a bookmark (best effort)
+func recordBookmarkUsage(ctx context.Context, p *searchPipeline, bookmarkID string) {
+	_ = p.store.IncrementBookmarkUsage(ctx, bookmarkID)
+	p.memIndex.IncrementBookmarkCounter(bookmarkID)
+}
 
-	// Find all endpoints that start with the query
-	for _, endpoint := range endpoints {
-		if strings.HasPrefix(endpoint, query) {
-			matches = append(matches, endpoint)
-		}
+// isAllowedService checks that a service and its custom redirect URL are allowed for redirection
+func isAllowedService(service *domain.Service, allowedDomains []string) bool {
+	if !isAllowedRedirect(service.BaseURL(), allowedDomains) {
+		return false
 	}
+	return service.RedirectURL == "" || isAllowedRedirect(service.RedirectURL, allowedDomains)
+}
 
-	// Return the match only if exactly one endpoint matches
-	if len(matches) == 1 {
-		return matches[0]
+// isAllowedRedirect checks if a target URL is allowed for redirection:
+// http(s) only, towards an allowed domain (the port and path do not matter)
+func isAllowedRedirect(target string, allowedDomains []string) bool {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return false
 	}
-
-	// Multiple or no matches - return empty
-	return ""
+	return domain.MatchesDomain(u.Hostname(), allowedDomains)
 }
@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
+)
+
+// serviceView is the JSON representation of a service in the services API
+type serviceView struct {
+	ID          string    `json:"id"`
+	Hostname    string    `json:"hostname"`
+	URL         string    `json:"url"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"display_name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Group       string    `json:"group,omitempty"`
+	Aliases     []string  `json:"aliases,omitempty"`
+	Boost       float64   `json:"boost,omitempty"`
+	Hidden      bool      `json:"hidden"`
+	Pinned      bool      `json:"pinned"`
+	Disabled    bool      `json:"disabled"`
+	RedirectURL string    `json:"redirect_url,omitempty"`
+	Sources     []string  `json:"sources"`
+	Counter     int64     `json:"counter"`
+	Frecency    float64   `json:"frecency"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	LastUsedAt  time.Time `json:"last_used_at"`
+}
+
+// serviceRequest is the body of a create or update request.
+// Fields left out are not changed; url is required on create and cannot be changed.
+type serviceRequest struct {
+	URL         *string   `json:"url"`
+	Name        *string   `json:"name"`
+	DisplayName *string   `json:"display_name"`
+	Description *string   `json:"description"`
+	Group       *string   `json:"group"`
+	Aliases     *[]string `json:"aliases"`
+	Boost       *float64  `json:"boost"`
+	Hidden      *bool     `json:"hidden"`
+	Pinned      *bool     `json:"pinned"`
+	Disabled    *bool     `json:"disabled"`
+	RedirectURL *string   `json:"redirect_url"`
+	Counter     *int64    `json:"counter"`
+	Frecency    *float64  `json:"frecency"`
+}
+
+type servicesResponse struct {
+	Services []serviceView `json:"services"`
+}
+
+// errInvalidServiceRequest is returned when a service request carries invalid values
+var errInvalidServiceRequest = errors.New("invalid service request")
+
+// ListServices returns every service of the index, disabled ones included.
+// ?source=api limits the list to one source.
+func ListServices(d deps.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		source := strings.TrimSpace(r.URL.Query().Get("source"))
+
+		services := d.MemoryIndex.GetAllServices()
+		sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+
+		response := servicesResponse{Services: make([]serviceView, 0, len(services))}
+		for _, service := range services {
+			if source != "" && !service.HasSource(source) {
+				continue
+			}
+			response.Services = append(response.Services, newServiceView(service))
+		}
+
+		writeJSON(w, http.StatusOK, response, d)
+	}
+}
+
+// GetService returns one service by ID
+func GetService(d deps.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		service, ok := serviceFromPath(w, r, d)
+		if !ok {
+			return
+		}
+		writeJSON(w, http.StatusOK, newServiceView(service), d)
+	}
+}
+
+// CreateService adds an ad-hoc service managed through the API.
+// Its ID is derived from the URL like for Homepage services.
+func CreateService(d deps.Deps) http.HandlerFunc {
+	store := redisstore.NewStore(d.RedisClient)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !requireJSON(w, r, d) {
+			return
+		}
+
+		var request serviceRequest
+		if err := decodeJSON(w, r, &request); err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid request body", d)
+			return
+		}
+		if request.URL == nil {
+			writeAPIError(w, http.StatusBadRequest, "url is required", d)
+			return
+		}
+
+		service, err := domain.NewService(*request.URL)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error(), d)
+			return
+		}
+		if !isAllowedService(service, d.AllowedDomains) {
+			writeAPIError(w, http.StatusBadRequest, "service is outside the allowed domains", d)
+			return
+		}
+		if _, exists := d.MemoryIndex.GetService(service.ID); exists {
+			writeAPIError(w, http.StatusConflict, "service already exists, update it instead", d)
+			return
+		}
+
+		request.URL = nil
+		if err := applyServiceRequest(service, request, d); err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error(), d)
+			return
+		}
+
+		now := time.Now()
+		service.Sources = []string{domain.SourceAPI}
+		service.CreatedAt = now
+		service.UpdatedAt = now
+		service.LastSeenAt = now
+
+		saveService(r.Context(), store, service, d)
+
+		d.Logger.Info("service created through the api",
+			logger.String("service_id", service.ID))
+
+		writeJSON(w, http.StatusCreated, newServiceView(service), d)
+	}
+}
+
+// UpdateService edits a service. The service becomes managed through the API:
+// Source reloads leave it alone until it is deleted through the API.
+func UpdateService(d deps.Deps) http.HandlerFunc {
+	store := redisstore.NewStore(d.RedisClient)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !requireJSON(w, r, d) {
+			return
+		}
+
+		existing, ok := serviceFromPath(w, r, d)
+		if !ok {
+			return
+		}
+
+		var request serviceRequest
+		if err := decodeJSON(w, r, &request); err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid request body", d)
+			return
+		}
+		if request.URL != nil {
+			writeAPIError(w, http.StatusBadRequest, "url cannot be changed, the service ID derives from it", d)
+			return
+		}
+
+		// Searches may be reading the indexed service: edit a copy
+		service := *existing
+		if err := applyServiceRequest(&service, request, d); err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error(), d)
+			return
+		}
+		if !service.HasSource(domain.SourceAPI) {
+			service.Sources = append(append([]string{}, service.Sources...), domain.SourceAPI)
+		}
+		service.UpdatedAt = time.Now()
+
+		saveService(r.Context(), store, &service, d)
+
+		d.Logger.Info("service updated through the api",
+			logger.String("service_id", service.ID))
+
+		writeJSON(w, http.StatusOK, newServiceView(&service), d)
+	}
+}
+
+// DeleteService removes a service created through the API.
+// A Homepage service edited through the API is handed back to Homepage instead:
+// the next reload restores its Homepage settings.
+func DeleteService(d deps.Deps) http.HandlerFunc {
+	store := redisstore.NewStore(d.RedisClient)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		existing, ok := serviceFromPath(w, r, d)
+		if !ok {
+			return
+		}
+
+		if !existing.HasSource(domain.SourceAPI) {
+			writeAPIError(w, http.StatusConflict, "service is managed by homepage, disable it instead", d)
+			return
+		}
+
+		if !existing.HasSource(domain.SourceHomepage) {
+			d.MemoryIndex.DeleteService(existing.ID)
+			if err := store.DeleteService(r.Context(), existing.ID); err != nil {
+				d.Logger.Warn("failed to delete service from redis",
+					logger.String("service_id", existing.ID),
+					logger.Error(err))
+			}
+
+			d.Logger.Info("service deleted through the api",
+				logger.String("service_id", existing.ID))
+
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		service := *existing
+		service.Sources = make([]string, 0, len(existing.Sources))
+		for _, source := range existing.Sources {
+			if source != domain.SourceAPI {
+				service.Sources = append(service.Sources, source)
+			}
+		}
+		service.UpdatedAt = time.Now()
+
+		saveService(r.Context(), store, &service, d)
+
+		// Restore the Homepage settings now rather than at the next periodic reload
+		select {
+		case d.ReloadTrigger <- struct{}{}:
+		default:
+		}
+
+		d.Logger.Info("service handed back to homepage",
+			logger.String("service_id", service.ID))
+
+		writeJSON(w, http.StatusOK, newServiceView(&service), d)
+	}
+}
+
+// serviceFromPath looks up the service named by the path ("/api/v1/services/{id}").
+// IDs may contain slashes ("domain.ext/grafana") and may be percent-encoded.
+func serviceFromPath(w http.ResponseWriter, r *http.Request, d deps.Deps) (*domain.Service, bool) {
+	id, err := url.PathUnescape(chi.URLParam(r, "*"))
+	if err != nil || id == "" {
+		writeAPIError(w, http.StatusBadRequest, "invalid service ID", d)
+		return nil, false
+	}
+
+	service, ok := d.MemoryIndex.GetService(strings.ToLower(id))
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "service not found", d)
+		return nil, false
+	}
+
+	return service, true
+}
+
+// applyServiceRequest copies the fields set in a request onto a service
+func applyServiceRequest(service *domain.Service, request serviceRequest, d deps.Deps) error {
+	if request.Name != nil {
+		name := strings.ToLower(strings.TrimSpace(*request.Name))
+		if name == "" {
+			return fmt.Errorf("%w: name cannot be empty", errInvalidServiceRequest)
+		}
+		service.Name = name
+	}
+	if request.DisplayName != nil {
+		service.DisplayName = strings.TrimSpace(*request.DisplayName)
+	}
+	if request.Description != nil {
+		service.Description = strings.TrimSpace(*request.Description)
+	}
+	if request.Group != nil {
+		service.Group = strings.TrimSpace(*request.Group)
+	}
+	if request.Aliases != nil {
+		service.Aliases = append([]string{}, *request.Aliases...)
+	}
+	if request.Boost != nil {
+		service.Boost = *request.Boost
+	}
+	if request.Hidden != nil {
+		service.Hidden = *request.Hidden
+	}
+	if request.Pinned != nil {
+		service.Pinned = *request.Pinned
+	}
+	if request.Disabled != nil {
+		service.Disabled = *request.Disabled
+	}
+	if request.RedirectURL != nil {
+		redirectURL := strings.TrimSpace(*request.RedirectURL)
+		if redirectURL != "" && !isAllowedRedirect(redirectURL, d.AllowedDomains) {
+			return fmt.Errorf("%w: redirect_url is outside the allowed domains", errInvalidServiceRequest)
+		}
+		service.RedirectURL = redirectURL
+	}
+	if request.Counter != nil {
+		if *request.Counter < 0 {
+			return fmt.Errorf("%w: counter cannot be negative", errInvalidServiceRequest)
+		}
+		service.Counter = *request.Counter
+	}
+	if request.Frecency != nil {
+		if *request.Frecency < 0 {
+			return fmt.Errorf("%w: frecency cannot be negative", errInvalidServiceRequest)
+		}
+		service.Frecency = *request.Frecency
+	}
+	return nil
+}
+
+// saveService stores a service in the memory index and in Redis (best effort)
+func saveService(ctx context.Context, store *redisstore.Store, service *domain.Service, d deps.Deps) {
+	d.MemoryIndex.AddService(service)
+	if err := store.SaveService(ctx, service); err != nil {
+		d.Logger.Warn("failed to save service to redis",
+			logger.String("service_id", service.ID),
+			logger.Error(err))
+	}
+}
+
+// newServiceView converts a service to its JSON representation
+func newServiceView(service *domain.Service) serviceView {
+	return serviceView{
+		ID:          service.ID,
+		Hostname:    service.Hostname,
+		URL:         service.BaseURL(),
+		Name:        service.Name,
+		DisplayName: service.DisplayName,
+		Description: service.Description,
+		Group:       service.Group,
+		Aliases:     service.Aliases,
+		Boost:       service.Boost,
+		Hidden:      service.Hidden,
+		Pinned:      service.Pinned,
+		Disabled:    service.Disabled,
+		RedirectURL: service.RedirectURL,
+		Sources:     service.Sources,
+		Counter:     service.Counter,
+		Frecency:    service.Frecency,
+		CreatedAt:   service.CreatedAt,
+		UpdatedAt:   service.UpdatedAt,
+		LastUsedAt:  service.LastUsedAt,
+	}
+}
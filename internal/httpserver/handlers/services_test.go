@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+)
+
+// newServicesRouter serves the services API like registerServices, without the access middlewares
+func newServicesRouter(d deps.Deps) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/api/v1/services", ListServices(d))
+	r.Post("/api/v1/services", CreateService(d))
+	r.Get("/api/v1/services/*", GetService(d))
+	r.Patch("/api/v1/services/*", UpdateService(d))
+	r.Delete("/api/v1/services/*", DeleteService(d))
+	return r
+}
+
+// serveAPI sends a request with an optional JSON body to a router
+func serveAPI(handler http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	r := httptest.NewRequest(method, target, reader)
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+// newServicesTestDeps returns deps with a Homepage service, an API service and a Homepage service edited through the API
+func newServicesTestDeps(t *testing.T) deps.Deps {
+	d := newTestDeps(t,
+		&domain.Service{ID: "jellyfin.domain.ext", Name: "jellyfin", Hostname: "jellyfin.domain.ext", Sources: []string{domain.SourceHomepage}},
+		&domain.Service{ID: "domain.ext/grafana", Name: "grafana", Hostname: "domain.ext", URL: "https://domain.ext/grafana", Sources: []string{domain.SourceAPI}},
+		&domain.Service{ID: "adguard.domain.ext", Name: "adguard", Hostname: "adguard.domain.ext", Sources: []string{domain.SourceHomepage, domain.SourceAPI}},
+	)
+	d.ReloadTrigger = make(chan struct{}, 1)
+	return d
+}
+
+func TestListServices(t *testing.T) {
+	router := newServicesRouter(newServicesTestDeps(t))
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"/api/v1/services", []string{"adguard.domain.ext", "domain.ext/grafana", "jellyfin.domain.ext"}},
+		{"/api/v1/services?source=api", []string{"adguard.domain.ext", "domain.ext/grafana"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := serveAPI(router, http.MethodGet, tt.target, "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", w.Code)
+			}
+			var response servicesResponse
+			decodeResponse(t, w, &response)
+
+			var ids []string
+			for _, service := range response.Services {
+				ids = append(ids, service.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("services = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetService(t *testing.T) {
+	router := newServicesRouter(newServicesTestDeps(t))
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+	}{
+		{"by hostname", "/api/v1/services/jellyfin.domain.ext", http.StatusOK},
+		{"id with a slash", "/api/v1/services/domain.ext/grafana", http.StatusOK},
+		{"escaped id", "/api/v1/services/domain.ext%2Fgrafana", http.StatusOK},
+		{"unknown", "/api/v1/services/nope.domain.ext", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serveAPI(router, http.MethodGet, tt.target, ""); w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestCreateService(t *testing.T) {
+	d := newServicesTestDeps(t)
+	router := newServicesRouter(d)
+
+	w := serveAPI(router, http.MethodPost, "/api/v1/services", `{"url": "https://nas.domain.ext:5001", "name": "nas", "aliases": ["storage"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201: %s", w.Code, w.Body.String())
+	}
+	var view serviceView
+	decodeResponse(t, w, &view)
+	if view.ID != "nas.domain.ext:5001" || view.Name != "nas" || len(view.Sources) != 1 || view.Sources[0] != domain.SourceAPI {
+		t.Errorf("created = %+v", view)
+	}
+	if _, ok := d.MemoryIndex.GetService("nas.domain.ext:5001"); !ok {
+		t.Error("created service is not in the index")
+	}
+
+	// The same URL cannot be created twice
+	if w := serveAPI(router, http.MethodPost, "/api/v1/services", `{"url": "https://nas.domain.ext:5001"}`); w.Code != http.StatusConflict {
+		t.Errorf("duplicate status = %d, want 409", w.Code)
+	}
+}
+
+func TestCreateService_InvalidRequests(t *testing.T) {
+	router := newServicesRouter(newServicesTestDeps(t))
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"missing url", `{"name": "nas"}`, http.StatusBadRequest},
+		{"unknown field", `{"url": "https://nas.domain.ext", "port": 5001}`, http.StatusBadRequest},
+		{"outside allowed domains", `{"url": "https://evil.example"}`, http.StatusBadRequest},
+		{"not a web url", `{"url": "ftp://nas.domain.ext"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serveAPI(router, http.MethodPost, "/api/v1/services", tt.body); w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+		})
+	}
+
+	// Forms and text bodies are rejected before decoding
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/services", strings.NewReader(`{"url": "https://nas.domain.ext"}`))
+	r.Header.Set("Content-Type", "text/plain")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("text/plain status = %d, want 415", w.Code)
+	}
+}
+
+func TestUpdateService(t *testing.T) {
+	d := newServicesTestDeps(t)
+	router := newServicesRouter(d)
+
+	w := serveAPI(router, http.MethodPatch, "/api/v1/services/jellyfin.domain.ext", `{"display_name": "Jellyfin", "boost": 5}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	// Editing a Homepage service hands it over to the API
+	service, _ := d.MemoryIndex.GetService("jellyfin.domain.ext")
+	if service.DisplayName != "Jellyfin" || service.Boost != 5 || !service.HasSource(domain.SourceAPI) || !service.HasSource(domain.SourceHomepage) {
+		t.Errorf("updated = %+v", service)
+	}
+
+	if w := serveAPI(router, http.MethodPatch, "/api/v1/services/jellyfin.domain.ext", `{"url": "https://other.domain.ext"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("url change status = %d, want 400", w.Code)
+	}
+	if w := serveAPI(router, http.MethodPatch, "/api/v1/services/nope.domain.ext", `{"boost": 1}`); w.Code != http.StatusNotFound {
+		t.Errorf("unknown service status = %d, want 404", w.Code)
+	}
+}
+
+func TestDeleteService(t *testing.T) {
+	d := newServicesTestDeps(t)
+	router := newServicesRouter(d)
+
+	// DELETE carries no body: it needs no content type
+	if w := serveAPI(router, http.MethodDelete, "/api/v1/services/domain.ext/grafana", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("api service status = %d, want 204: %s", w.Code, w.Body.String())
+	}
+	if _, ok := d.MemoryIndex.GetService("domain.ext/grafana"); ok {
+		t.Error("deleted service is still in the index")
+	}
+
+	if w := serveAPI(router, http.MethodDelete, "/api/v1/services/jellyfin.domain.ext", ""); w.Code != http.StatusConflict {
+		t.Errorf("homepage service status = %d, want 409", w.Code)
+	}
+
+	// An edited Homepage service is handed back to Homepage
+	w := serveAPI(router, http.MethodDelete, "/api/v1/services/adguard.domain.ext", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("edited service status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	service, ok := d.MemoryIndex.GetService("adguard.domain.ext")
+	if !ok || service.HasSource(domain.SourceAPI) {
+		t.Errorf("handed back = %+v, want a Homepage-only service", service)
+	}
+	if len(d.ReloadTrigger) != 1 {
+		t.Error("handing a service back did not trigger a reload")
+	}
+}
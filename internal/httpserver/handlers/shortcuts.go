@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+type shortcutsPage struct {
+	Query     string
+	Shortcuts []domain.Shortcut
+}
+
+var shortcutsTemplate = template.Must(template.New("shortcuts").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Jump: {{.Query}}</title>
+<style>
+  body { font-family: system-ui, sans-serif; background: #111; color: #eee; display: flex; justify-content: center; margin: 0; padding: 4rem 1rem; }
+  main { width: 100%; max-width: 32rem; }
+  h1 { font-size: 1rem; font-weight: normal; color: #aaa; }
+  ul { list-style: none; padding: 0; margin: 0; }
+  a, span.item { display: flex; gap: 1rem; align-items: baseline; margin: 0.25rem 0; padding: 0.75rem 1rem; border: 1px solid #333; border-radius: 0.5rem; background: #1b1b1b; color: inherit; text-decoration: none; }
+  a:hover, a:focus { border-color: #6cf; outline: none; background: #1f2a33; }
+  span.item { color: #666; }
+  code { color: #6cf; }
+  span.item code { color: #666; }
+  .detail { color: #888; font-size: 0.85rem; margin-left: auto; }
+</style>
+</head>
+<body>
+<main>
+<h1>{{if eq .Query "/"}}Jump shortcuts{{else}}Several shortcuts match <strong>{{.Query}}</strong>{{end}}</h1>
+<ul>
+{{- range .Shortcuts}}
+<li>{{if .Safe}}<a href="{{.Path}}"><code>{{.Path}}</code><span class="detail">{{.Description}}</span></a>{{else}}<span class="item"><code>{{.Path}}</code><span class="detail">{{.Description}} (not from the address bar)</span></span>{{end}}</li>
+{{- end}}
+</ul>
+</main>
+</body>
+</html>
+`))
+
+// shortcutResolution is the outcome of matching a "/" query against the shortcuts
+type shortcutResolution struct {
+	Candidates []*domain.ShortcutCandidate
+	Shortcut   *domain.Shortcut  // selected shortcut, nil when none or several match
+	Choices    []domain.Shortcut // shortcuts tied for the best score
+	Reason     string
+}
+
+// resolveShortcut ranks the shortcuts for a "/" query.
+// The best shortcut is selected unless another one scores the same.
+func resolveShortcut(query string, shortcuts []domain.Shortcut) *shortcutResolution {
+	res := &shortcutResolution{Candidates: domain.RankShortcuts(query, shortcuts)}
+
+	switch {
+	case len(res.Candidates) == 0:
+		res.Reason = ReasonNoMatch
+	case len(res.Candidates) == 1 || res.Candidates[0].Score > res.Candidates[1].Score:
+		res.Shortcut = &res.Candidates[0].Shortcut
+		res.Reason = ReasonRanked
+	default:
+		for _, candidate := range res.Candidates {
+			if candidate.Score == res.Candidates[0].Score {
+				res.Choices = append(res.Choices, candidate.Shortcut)
+			}
+		}
+		res.Reason = ReasonAmbiguous
+	}
+
+	return res
+}
+
+// handleInternalEndpoint handles internal endpoint routing:
+// "/" lists the shortcuts, other queries redirect to the best matching one
+func handleInternalEndpoint(w http.ResponseWriter, r *http.Request, query string, d deps.Deps) {
+	if strings.TrimSpace(query) == domain.ShortcutPrefix {
+		renderShortcuts(w, shortcutsPage{Query: domain.ShortcutPrefix, Shortcuts: d.Shortcuts}, d)
+		return
+	}
+
+	res := resolveShortcut(query, d.Shortcuts)
+	switch {
+	case res.Shortcut != nil:
+		d.Logger.Info("internal endpoint redirect",
+			logger.String("query", query),
+			logger.String("endpoint", res.Shortcut.Path))
+		// Relative redirect: the browser keeps the scheme and host Jump was reached with
+		http.Redirect(w, r, res.Shortcut.Path, http.StatusFound)
+	case len(res.Choices) > 0:
+		d.Logger.Info("ambiguous internal endpoint, showing shortcuts",
+			logger.String("query", query),
+			logger.Int("choices", len(res.Choices)))
+		renderShortcuts(w, shortcutsPage{Query: query, Shortcuts: res.Choices}, d)
+	default:
+		// No match found, redirect to homepage
+		d.Logger.Debug("no internal endpoint matched",
+			logger.String("query", query))
+		http.Redirect(w, r, d.HomepageURL, http.StatusFound)
+	}
+}
+
+// renderShortcuts writes the shortcuts page
+func renderShortcuts(w http.ResponseWriter, page shortcutsPage, d deps.Deps) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := shortcutsTemplate.Execute(w, page); err != nil {
+		d.Logger.Debug("failed to write response", logger.Error(err))
+	}
+}
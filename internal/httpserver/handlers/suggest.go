@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/index"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+// MaxSuggestions is the maximum number of entries returned by /suggest
+const MaxSuggestions = 8
+
+// suggestions holds the three parallel lists of the OpenSearch suggestions format
+type suggestions struct {
+	completions  []string
+	descriptions []string
+	urls         []string
+}
+
+func (s *suggestions) add(completion, description, url string) {
+	s.completions = append(s.completions, completion)
+	s.descriptions = append(s.descriptions, description)
+	s.urls = append(s.urls, url)
+}
+
+// Suggest returns live suggestions in the OpenSearch suggestions JSON format:
+// [query, [completions], [descriptions], [urls]]
+// It only ranks candidates: no TLS validation, no usage counters, no cache writes.
+func Suggest(d deps.Deps) http.HandlerFunc {
+	memIndex := d.MemoryIndex
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+		s := &suggestions{
+			completions:  []string{},
+			descriptions: []string{},
+			urls:         []string{},
+		}
+
+		switch {
+		case query == "", strings.HasPrefix(query, "/"):
+			// Nothing to suggest
+		case strings.HasPrefix(query, domain.BangPrefix):
+			suggestBangs(s, query, d)
+		case strings.HasPrefix(query, "@"):
+			suggestBookmarks(s, strings.TrimSpace(strings.TrimPrefix(query, "@")), memIndex)
+		case d.UnifiedSearch:
+			suggestUnified(s, query, memIndex, d)
+		default:
+			suggestServices(s, query, memIndex, d)
+		}
+
+		w.Header().Set("Content-Type", suggestionsMIMEType+"; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode([]interface{}{query, s.completions, s.descriptions, s.urls}); err != nil {
+			d.Logger.Debug("failed to write response", logger.Error(err))
+		}
+	}
+}
+
+// suggestServices fills suggestions with ranked services
+func suggestServices(s *suggestions, query string, memIndex *index.MemoryIndex, d deps.Deps) {
+	candidates := suggestableServices(domain.ParseQuery(query), memIndex, d)
+	names := serviceNameCounts(candidates)
+
+	for _, candidate := range candidates {
+		if len(s.completions) >= MaxSuggestions {
+			break
+		}
+		addServiceSuggestion(s, candidate, names)
+	}
+}
+
+// suggestUnified fills suggestions with services and bookmarks ranked together.
+// Bookmarks are completed with their @ abbreviation so picking one targets it explicitly.
+func suggestUnified(s *suggestions, query string, memIndex *index.MemoryIndex, d deps.Deps) {
+	parsed := domain.ParseQuery(query)
+	candidates := suggestableServices(parsed, memIndex, d)
+	names := serviceNameCounts(candidates)
+
+	// Group-scoped and deep-link queries only target services
+	var bookmarks []*domain.BookmarkCandidate
+	if parsed.Raw != "" && parsed.Group == "" && parsed.Path == "" {
+		bookmarks = domain.RankBookmarkCandidates(parsed.Raw, memIndex.GetAllBookmarks())
+	}
+
+	for _, match := range domain.MergeMatches(candidates, bookmarks) {
+		if len(s.completions) >= MaxSuggestions {
+			break
+		}
+		if match.Kind == domain.MatchKindBookmark {
+			addBookmarkSuggestion(s, match.Bookmark)
+			continue
+		}
+		addServiceSuggestion(s, match.Service, names)
+	}
+}
+
+// suggestableServices ranks the services a query may suggest (visible and allowed)
+func suggestableServices(parsed *domain.Query, memIndex *index.MemoryIndex, d deps.Deps) []*domain.Candidate {
+	candidates := domain.RankCandidates(parsed, memIndex.GetAllServices())
+
+	visible := make([]*domain.Candidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.Service.Hidden || !isAllowedService(candidate.Service, d.AllowedDomains) {
+			continue
+		}
+		visible = append(visible, candidate)
+	}
+	return visible
+}
+
+// serviceNameCounts counts short names to know when a name is ambiguous
+func serviceNameCounts(candidates []*domain.Candidate) map[string]int {
+	names := make(map[string]int, len(candidates))
+	for _, candidate := range candidates {
+		names[candidate.Service.Name]++
+	}
+	return names
+}
+
+// addServiceSuggestion adds a service, preferring the short name
+// and falling back to the unique ID when the name is shared
+func addServiceSuggestion(s *suggestions, candidate *domain.Candidate, names map[string]int) {
+	id := candidate.Service.ID
+	completion := candidate.Service.Name
+	if completion == "" || names[completion] > 1 {
+		completion = id
+	}
+
+	target, err := candidate.Service.TargetURL("")
+	if err != nil {
+		return
+	}
+	s.add(completion, id, target)
+}
+
+// suggestBookmarks fills suggestions with ranked bookmarks
+func suggestBookmarks(s *suggestions, query string, memIndex *index.MemoryIndex) {
+	if query == "" {
+		return
+	}
+
+	for _, candidate := range domain.RankBookmarkCandidates(query, memIndex.GetAllBookmarks()) {
+		if len(s.completions) >= MaxSuggestions {
+			break
+		}
+		addBookmarkSuggestion(s, candidate)
+	}
+}
+
+// addBookmarkSuggestion adds a bookmark completed with its @ abbreviation
+func addBookmarkSuggestion(s *suggestions, candidate *domain.BookmarkCandidate) {
+	description := candidate.Bookmark.Name
+	if description == "" {
+		description = candidate.Bookmark.URL
+	}
+	s.add("@"+candidate.Bookmark.Abbr, description, candidate.Bookmark.URL)
+}
+
+// suggestBangs fills suggestions with the search engines matching a bang prefix
+func suggestBangs(s *suggestions, query string, d deps.Deps) {
+	prefix, text, _ := strings.Cut(strings.TrimPrefix(query, domain.BangPrefix), " ")
+	prefix = strings.ToLower(prefix)
+
+	names := make([]string, 0, len(d.SearchEngines))
+	for name := range d.SearchEngines {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if len(s.completions) >= MaxSuggestions {
+			break
+		}
+		template := d.SearchEngines[name]
+		s.add(domain.BangPrefix+name+" "+text, template, domain.SearchURL(template, strings.TrimSpace(text)))
+	}
+}
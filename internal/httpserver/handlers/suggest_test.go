@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+)
+
+// suggest calls the Suggest handler and decodes the OpenSearch suggestions response
+func suggest(t *testing.T, handler http.Handler, query string) (completions, urls []string) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/suggest?q="+url.QueryEscape(query), nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != suggestionsMIMEType+"; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+
+	var response []json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || len(response) != 4 {
+		t.Fatalf("invalid suggestions response %s: %v", w.Body.String(), err)
+	}
+	var echoed string
+	if err := json.Unmarshal(response[0], &echoed); err != nil || echoed != query {
+		t.Errorf("query = %q, want %q", echoed, query)
+	}
+	if err := json.Unmarshal(response[1], &completions); err != nil {
+		t.Fatalf("invalid completions: %v", err)
+	}
+	if err := json.Unmarshal(response[3], &urls); err != nil {
+		t.Fatalf("invalid urls: %v", err)
+	}
+	return completions, urls
+}
+
+func TestSuggest(t *testing.T) {
+	services := append(testServices(),
+		&domain.Service{ID: "jellytv.other.ext", Name: "jellytv", Hostname: "jellytv.other.ext"})
+	handler := Suggest(newTestDeps(t, services...))
+
+	completions, urls := suggest(t, handler, "jel")
+	if len(completions) != 2 || len(urls) != 2 {
+		t.Fatalf("completions = %v, want the two allowed jelly services", completions)
+	}
+	for i, completion := range completions {
+		if completion != "jellyfin" && completion != "jellyseerr" {
+			t.Errorf("unexpected completion %q", completion)
+		}
+		if urls[i] != "https://"+completion+".domain.ext" {
+			t.Errorf("url of %q = %q", completion, urls[i])
+		}
+	}
+}
+
+func TestSuggest_NothingToSuggest(t *testing.T) {
+	handler := Suggest(newTestDeps(t, testServices()...))
+
+	for _, query := range []string{"", "/infra", "zzzz"} {
+		if completions, _ := suggest(t, handler, query); len(completions) != 0 {
+			t.Errorf("suggest(%q) = %v, want none", query, completions)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+)
+
+// bangURL returns the search URL for a bang query ("!gh jump").
+// ok is false when the query is not a bang or names an unknown engine.
+func bangURL(query string, d deps.Deps) (engine, target string, ok bool) {
+	name, text, ok := domain.ParseBang(query)
+	if !ok {
+		return "", "", false
+	}
+
+	template, ok := d.SearchEngines[name]
+	if !ok {
+		return "", "", false
+	}
+
+	return name, domain.SearchURL(template, text), true
+}
+
+// fallbackURL returns where a query that matched nothing is sent:
+// the fallback search engine when configured, HomepageURL otherwise
+func fallbackURL(query string, d deps.Deps) string {
+	if query == "" || d.FallbackEngine == "" {
+		return d.HomepageURL
+	}
+
+	template, ok := d.SearchEngines[d.FallbackEngine]
+	if !ok {
+		return d.HomepageURL
+	}
+
+	return domain.SearchURL(template, query)
+}
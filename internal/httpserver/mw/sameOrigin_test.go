@@ -0,0 +1,44 @@
+package mw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+func TestSameOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"no browser headers", nil, http.StatusNoContent},
+		{"same origin fetch", map[string]string{"Sec-Fetch-Site": "same-origin"}, http.StatusNoContent},
+		{"typed in the address bar", map[string]string{"Sec-Fetch-Site": "none"}, http.StatusNoContent},
+		{"cross site fetch", map[string]string{"Sec-Fetch-Site": "cross-site", "Origin": "https://jump.lan"}, http.StatusForbidden},
+		{"same site fetch", map[string]string{"Sec-Fetch-Site": "same-site"}, http.StatusForbidden},
+		{"matching origin", map[string]string{"Origin": "https://jump.lan"}, http.StatusNoContent},
+		{"other origin", map[string]string{"Origin": "https://evil.example"}, http.StatusForbidden},
+		{"opaque origin", map[string]string{"Origin": "null"}, http.StatusForbidden},
+	}
+
+	handler := SameOrigin(logger.New("error", false))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "http://jump.lan/feedback", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/handlers"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/mw"
+)
+
+func init() {
+	Register(registerExport, WithShortcut("/api/v1/export", "export", "Export learning state", true))
+}
+
+func registerExport(r chi.Router, d deps.Deps) {
+	protected := r.With(mw.AllowOnlyCIDRS(d.AllowedCIDRS, d.TrustProxy, d.Logger), mw.EnforceHost(d.AllowedHosts, d.Logger))
+	protected.Get("/api/v1/export", handlers.Export(d))
+	protected.Post("/api/v1/import", handlers.Import(d))
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/MrSnakeDoc/jump/internal/httpserver/mw"
 )
 
-func init() { Register(registerHealthz) }
+func init() { Register(registerHealthz, WithShortcut("/healthz", "healthz", "Health check", true)) }
 
 func registerHealthz(r chi.Router, d deps.Deps) {
 	r.With(mw.AllowOnlyCIDRS(d.AllowedCIDRS, d.TrustProxy, d.Logger)).Get("/healthz", handlers.Healthz(d))
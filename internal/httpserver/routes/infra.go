@@ -8,7 +8,7 @@ import (
 	"github.com/MrSnakeDoc/jump/internal/httpserver/mw"
 )
 
-func init() { Register(registerInfra) }
+func init() { Register(registerInfra, WithShortcut("/infra", "infra", "Infrastructure status", true)) }
 
 func registerInfra(r chi.Router, d deps.Deps) {
 	r.With(mw.AllowOnlyCIDRS(d.AllowedCIDRS, d.TrustProxy, d.Logger), mw.EnforceHost(d.AllowedHosts, d.Logger)).Get("/infra", handlers.Infra(d))
@@ -8,7 +8,7 @@ import (
 	"github.com/MrSnakeDoc/jump/internal/httpserver/mw"
 )
 
-func init() { Register(registerReadyz) }
+func init() { Register(registerReadyz, WithShortcut("/readyz", "readyz", "Readiness probe", true)) }
 
 func registerReadyz(r chi.Router, d deps.Deps) {
 	r.With(mw.AllowOnlyCIDRS(d.AllowedCIDRS, d.TrustProxy, d.Logger)).Get("/readyz", handlers.Readyz(d))
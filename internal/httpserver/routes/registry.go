@@ -2,31 +2,65 @@ package routes
 
 import (
 	"net/http"
+	"sort"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/MrSnakeDoc/jump/internal/domain"
 	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
 )
 
 type (
 	Registrar  func(r chi.Router, d deps.Deps)
 	Middleware = func(http.Handler) http.Handler
+	Option     func(e *entry)
 )
 
 type entry struct {
-	reg Registrar
-	mws []Middleware
+	reg      Registrar
+	mws      []Middleware
+	shortcut *domain.Shortcut
 }
 
 var registry []entry
 
-// Register a registrar with optional per-route middlewares.
-func Register(reg Registrar, mws ...Middleware) {
-	registry = append(registry, entry{reg: reg, mws: mws})
+// Register a registrar with optional per-route middlewares and shortcut.
+func Register(reg Registrar, opts ...Option) {
+	e := entry{reg: reg}
+	for _, opt := range opts {
+		opt(&e)
+	}
+	registry = append(registry, e)
+}
+
+// WithMiddlewares applies middlewares to every route of the registrar.
+func WithMiddlewares(mws ...Middleware) Option {
+	return func(e *entry) { e.mws = append(e.mws, mws...) }
+}
+
+// WithShortcut exposes a route of the registrar to "/" queries (jp /inf -> /infra).
+// Safe routes (GET) can be opened from the address bar; the others are listed only.
+func WithShortcut(path, name, description string, safe bool) Option {
+	return func(e *entry) {
+		e.shortcut = &domain.Shortcut{Path: path, Name: name, Description: description, Safe: safe}
+	}
+}
+
+// Shortcuts returns the shortcuts of the registered routes, ordered by path.
+func Shortcuts() []domain.Shortcut {
+	var list []domain.Shortcut
+	for _, e := range registry {
+		if e.shortcut != nil {
+			list = append(list, *e.shortcut)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	return list
 }
 
 // Called once from server.New()
 func RegisterAll(r chi.Router, d deps.Deps) {
+	d.Shortcuts = Shortcuts()
 	for _, e := range registry {
 		if len(e.mws) == 0 {
 			e.reg(r, d)
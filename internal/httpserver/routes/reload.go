@@ -8,7 +8,9 @@ import (
 	"github.com/MrSnakeDoc/jump/internal/httpserver/mw"
 )
 
-func init() { Register(registerReload) }
+func init() {
+	Register(registerReload, WithShortcut("/reload", "reload", "Reload services and bookmarks", false))
+}
 
 func registerReload(r chi.Router, d deps.Deps) {
 	r.With(mw.AllowOnlyCIDRS(d.AllowedCIDRS, d.TrustProxy, d.Logger), mw.EnforceHost(d.AllowedHosts, d.Logger)).Post("/reload", handlers.Reload(d))
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrSnakeDoc/jump/internal/httpserver/deps"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/handlers"
+	"github.com/MrSnakeDoc/jump/internal/httpserver/mw"
+)
+
+func init() {
+	Register(registerServices, WithShortcut("/api/v1/services", "services", "Manage services at runtime", true))
+}
+
+// Service IDs may contain slashes ("domain.ext/grafana"): they are matched by the wildcard
+func registerServices(r chi.Router, d deps.Deps) {
+	protected := r.With(mw.AllowOnlyCIDRS(d.AllowedCIDRS, d.TrustProxy, d.Logger), mw.EnforceHost(d.AllowedHosts, d.Logger))
+	protected.Get("/api/v1/services", handlers.ListServices(d))
+	protected.Post("/api/v1/services", handlers.CreateService(d))
+	protected.Get("/api/v1/services/*", handlers.GetService(d))
+	protected.Patch("/api/v1/services/*", handlers.UpdateService(d))
+	protected.Delete("/api/v1/services/*", handlers.DeleteService(d))
+}
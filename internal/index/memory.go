@@ -13,8 +13,12 @@ type MemoryIndex struct {
 	mu                 sync.RWMutex
 	services           map[string]*domain.Service  // ID -> Service
 	bookmarks          map[string]*domain.Bookmark // ID -> Bookmark
+	health             map[string]*domain.Health   // Service ID -> last probe result
 	lastReload         time.Time                   // Timestamp of last services reload
 	lastBookmarkReload time.Time                   // Timestamp of last bookmarks reload
+	rejectedBookmarks  int                         // Bookmarks rejected by the URL policy at last reload
+	lastProbe          time.Time                   // Timestamp of last health probe round
+	lastDecay          time.Time                   // Timestamp of last frecency decay
 }
 
 // NewMemoryIndex creates a new memory index
@@ -22,6 +26,7 @@ func NewMemoryIndex() *MemoryIndex {
 	return &MemoryIndex{
 		services:  make(map[string]*domain.Service),
 		bookmarks: make(map[string]*domain.Bookmark),
+		health:    make(map[string]*domain.Health),
 	}
 }
 
@@ -73,6 +78,7 @@ func (idx *MemoryIndex) DeleteService(id string) {
 	defer idx.mu.Unlock()
 
 	delete(idx.services, id)
+	delete(idx.health, id)
 }
 
 // Count returns the number of services in the index
@@ -83,16 +89,47 @@ func (idx *MemoryIndex) Count() int {
 	return len(idx.services)
 }
 
-// IncrementCounter increments the usage counter for a service
+// IncrementCounter records a redirect to a service (counter, frecency and last use).
+// Indexed services are read without the lock: the change is made on a copy swapped into the index.
 func (idx *MemoryIndex) IncrementCounter(id string) {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
 
 	if service, ok := idx.services[id]; ok {
-		service.Counter++
+		updated := *service
+		updated.RecordUse(time.Now())
+		idx.services[id] = &updated
 	}
 }
 
+// DecayFrecency applies a decay factor to the frecency of every service
+// and returns the services that were changed (copies swapped into the index)
+func (idx *MemoryIndex) DecayFrecency(factor float64) []*domain.Service {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	changed := make([]*domain.Service, 0)
+	for id, service := range idx.services {
+		if service.Frecency == 0 {
+			continue
+		}
+		updated := *service
+		updated.Decay(factor)
+		idx.services[id] = &updated
+		changed = append(changed, &updated)
+	}
+	idx.lastDecay = time.Now()
+	return changed
+}
+
+// GetLastDecay returns the timestamp of the last frecency decay
+func (idx *MemoryIndex) GetLastDecay() time.Time {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return idx.lastDecay
+}
+
 // GetLastReload returns the timestamp of the last services reload
 func (idx *MemoryIndex) GetLastReload() time.Time {
 	idx.mu.RLock()
@@ -155,6 +192,38 @@ func (idx *MemoryIndex) DeleteBookmark(id string) {
 	delete(idx.bookmarks, id)
 }
 
+// IncrementBookmarkCounter records a redirect to a bookmark (counter, frecency and last use),
+// on a copy swapped into the index like services
+func (idx *MemoryIndex) IncrementBookmarkCounter(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if bookmark, ok := idx.bookmarks[id]; ok {
+		updated := *bookmark
+		updated.RecordUse(time.Now())
+		idx.bookmarks[id] = &updated
+	}
+}
+
+// DecayBookmarkFrecency applies a decay factor to the frecency of every bookmark
+// and returns the bookmarks that were changed (copies swapped into the index)
+func (idx *MemoryIndex) DecayBookmarkFrecency(factor float64) []*domain.Bookmark {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	changed := make([]*domain.Bookmark, 0)
+	for id, bookmark := range idx.bookmarks {
+		if bookmark.Frecency == 0 {
+			continue
+		}
+		updated := *bookmark
+		updated.Decay(factor)
+		idx.bookmarks[id] = &updated
+		changed = append(changed, &updated)
+	}
+	return changed
+}
+
 // BookmarkCount returns the number of bookmarks in the index
 func (idx *MemoryIndex) BookmarkCount() int {
 	idx.mu.RLock()
@@ -170,3 +239,70 @@ func (idx *MemoryIndex) GetLastBookmarkReload() time.Time {
 
 	return idx.lastBookmarkReload
 }
+
+// SetRejectedBookmarks records how many bookmarks the URL policy rejected at the last reload
+func (idx *MemoryIndex) SetRejectedBookmarks(count int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.rejectedBookmarks = count
+}
+
+// RejectedBookmarks returns how many bookmarks the URL policy rejected at the last reload
+func (idx *MemoryIndex) RejectedBookmarks() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return idx.rejectedBookmarks
+}
+
+// ─────────────────────────────────────────────────────────────────
+// Health methods
+// ─────────────────────────────────────────────────────────────────
+
+// UpdateHealth stores the results of a probe round
+func (idx *MemoryIndex) UpdateHealth(results []*domain.Health) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for _, h := range results {
+		idx.health[h.ServiceID] = h
+	}
+	idx.lastProbe = time.Now()
+}
+
+// GetHealth retrieves the last probe result of a service
+func (idx *MemoryIndex) GetHealth(id string) (*domain.Health, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	h, ok := idx.health[id]
+	return h, ok
+}
+
+// HealthCounts returns the number of active services seen up and down by the last probes
+func (idx *MemoryIndex) HealthCounts() (up, down int) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	for id, h := range idx.health {
+		service, ok := idx.services[id]
+		if !ok || service.Disabled {
+			continue
+		}
+		if h.Up {
+			up++
+		} else {
+			down++
+		}
+	}
+	return up, down
+}
+
+// GetLastProbe returns the timestamp of the last health probe round
+func (idx *MemoryIndex) GetLastProbe() time.Time {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return idx.lastProbe
+}
@@ -112,6 +112,25 @@ func TestIncrementCounterNonExistent(t *testing.T) {
 	}
 }
 
+func TestIncrementBookmarkCounter(t *testing.T) {
+	index := NewMemoryIndex()
+
+	index.UpdateBookmarks([]*domain.Bookmark{
+		{ID: "github", Abbr: "GitHub", URL: "https://github.com"},
+	})
+
+	index.IncrementBookmarkCounter("github")
+	index.IncrementBookmarkCounter("nonexistent")
+
+	bookmark, ok := index.GetBookmark("github")
+	if !ok {
+		t.Fatal("bookmark not found")
+	}
+	if bookmark.Counter != 1 || bookmark.Frecency != 1 || bookmark.LastUsedAt.IsZero() {
+		t.Errorf("IncrementBookmarkCounter() = counter %v, frecency %v, last used %v", bookmark.Counter, bookmark.Frecency, bookmark.LastUsedAt)
+	}
+}
+
 func TestConcurrentAccess(t *testing.T) {
 	index := NewMemoryIndex()
 
@@ -181,3 +200,49 @@ func TestGetAllServicesReturnsSnapshot(t *testing.T) {
 		t.Error("GetAllServices() should return references to the same service objects")
 	}
 }
+
+func TestConcurrentUsageAndDecay(t *testing.T) {
+	index := NewMemoryIndex()
+	index.UpdateServices([]*domain.Service{
+		{ID: "service1", Name: "service1", Hostname: "service1.example.com", Frecency: 1},
+	})
+
+	var wg sync.WaitGroup
+
+	// Searches read the fields of indexed services without holding the lock
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, svc := range index.GetAllServices() {
+				_ = svc.Counter + int64(svc.Frecency)
+				_ = svc.LastUsedAt.IsZero()
+			}
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			index.IncrementCounter("service1")
+		}()
+		go func() {
+			defer wg.Done()
+			// The decayed services are persisted outside the lock
+			for _, svc := range index.DecayFrecency(0.99) {
+				_ = svc.Frecency
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	svc, ok := index.GetService("service1")
+	if !ok {
+		t.Fatal("service not found")
+	}
+	if svc.Counter != 50 {
+		t.Errorf("counter = %v, want 50", svc.Counter)
+	}
+}
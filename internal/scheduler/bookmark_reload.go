@@ -5,17 +5,15 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/MrSnakeDoc/jump/internal/domain"
 	"github.com/MrSnakeDoc/jump/internal/index"
 	"github.com/MrSnakeDoc/jump/internal/logger"
-	"github.com/MrSnakeDoc/jump/internal/sources/homepage"
+	"github.com/MrSnakeDoc/jump/internal/sources"
 	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
 )
 
-// BookmarkReloader handles periodic reloading of homepage bookmarks
+// BookmarkReloader handles periodic reloading of bookmarks from the enabled sources
 type BookmarkReloader struct {
-	loader        *homepage.BookmarkLoader
-	mapper        *homepage.BookmarkMapper
+	reconciler    *sources.Reconciler
 	store         *redisstore.Store
 	index         *index.MemoryIndex
 	logger        logger.Logger
@@ -26,7 +24,7 @@ type BookmarkReloader struct {
 
 // NewBookmarkReloader creates a new bookmark reloader
 func NewBookmarkReloader(
-	bookmarkFile string,
+	reconciler *sources.Reconciler,
 	store *redisstore.Store,
 	idx *index.MemoryIndex,
 	log logger.Logger,
@@ -34,8 +32,7 @@ func NewBookmarkReloader(
 	manualTrigger chan struct{},
 ) *BookmarkReloader {
 	return &BookmarkReloader{
-		loader:        homepage.NewBookmarkLoader(bookmarkFile),
-		mapper:        homepage.NewBookmarkMapper(),
+		reconciler:    reconciler,
 		store:         store,
 		index:         idx,
 		logger:        log,
@@ -85,59 +82,22 @@ func (br *BookmarkReloader) Stop() {
 	close(br.stopCh)
 }
 
-// Reload loads bookmarks from homepage and updates store + index
+// Reload loads bookmarks from every source and updates store + index
 func (br *BookmarkReloader) Reload(ctx context.Context) error {
-	br.logger.Info("reloading bookmarks from homepage")
+	br.logger.Info("reloading bookmarks from sources")
 
-	// Load and parse bookmarks.yaml
-	config, err := br.loader.Load()
+	bookmarks, rejected, err := br.reconciler.Bookmarks(ctx, br.index.GetAllBookmarks())
 	if err != nil {
-		return fmt.Errorf("failed to load bookmarks: %w", err)
+		return err
 	}
-
-	// Map to domain bookmarks
-	newBookmarks, err := br.mapper.MapBookmarks(config)
-	if err != nil {
-		return fmt.Errorf("failed to map bookmarks: %w", err)
-	}
-
-	br.logger.Info("loaded bookmarks from homepage",
-		logger.Int("count", len(newBookmarks)))
-
-	// Get existing bookmarks from homepage source to detect removals
-	existingBookmarks := br.getHomepageBookmarks()
-
-	// Build map of new bookmark IDs for quick lookup
-	newBookmarkIDs := make(map[string]bool, len(newBookmarks))
-	for _, bm := range newBookmarks {
-		newBookmarkIDs[bm.ID] = true
-	}
-
-	// Find bookmarks that were removed from homepage
-	var disabledBookmarks []*domain.Bookmark
-	for _, existing := range existingBookmarks {
-		if !newBookmarkIDs[existing.ID] {
-			// Bookmark no longer in homepage - mark as disabled
-			existing.Disabled = true
-			existing.UpdatedAt = time.Now()
-			disabledBookmarks = append(disabledBookmarks, existing)
-		}
-	}
-
-	if len(disabledBookmarks) > 0 {
-		br.logger.Info("marking removed bookmarks as disabled",
-			logger.Int("count", len(disabledBookmarks)))
-	}
-
-	// Combine active and disabled bookmarks for storage
-	newBookmarks = append(newBookmarks, disabledBookmarks...)
+	br.index.SetRejectedBookmarks(rejected)
 
 	// Update memory index
-	br.index.UpdateBookmarks(newBookmarks)
+	br.index.UpdateBookmarks(bookmarks)
 
 	// Update Redis store (best effort)
 	if br.store != nil {
-		if err := br.store.SaveBookmarksMany(ctx, newBookmarks); err != nil {
+		if err := br.store.SaveBookmarksMany(ctx, bookmarks); err != nil {
 			br.logger.Warn("failed to save bookmarks to redis",
 				logger.Error(err))
 			// Don't fail - memory index is the primary source
@@ -148,21 +108,3 @@ func (br *BookmarkReloader) Reload(ctx context.Context) error {
 
 	return nil
 }
-
-// getHomepageBookmarks returns existing bookmarks that came from homepage source
-func (br *BookmarkReloader) getHomepageBookmarks() []*domain.Bookmark {
-	all := br.index.GetAllBookmarks()
-	var homepageBookmarks []*domain.Bookmark
-
-	for _, bm := range all {
-		// Check if bookmark has homepage in its sources
-		for _, source := range bm.Sources {
-			if source == "homepage" {
-				homepageBookmarks = append(homepageBookmarks, bm)
-				break
-			}
-		}
-	}
-
-	return homepageBookmarks
-}
@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/index"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
+)
+
+// FrecencyDecayer periodically decays usage so that recent redirects weigh more than old ones
+type FrecencyDecayer struct {
+	store    *redisstore.Store
+	index    *index.MemoryIndex
+	logger   logger.Logger
+	interval time.Duration
+	halfLife time.Duration
+	stopCh   chan struct{}
+}
+
+// NewFrecencyDecayer creates a new frecency decayer
+func NewFrecencyDecayer(
+	store *redisstore.Store,
+	idx *index.MemoryIndex,
+	log logger.Logger,
+	interval time.Duration,
+	halfLife time.Duration,
+) *FrecencyDecayer {
+	return &FrecencyDecayer{
+		store:    store,
+		index:    idx,
+		logger:   log,
+		interval: interval,
+		halfLife: halfLife,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start begins the periodic decay.
+// The time of the last decay is kept in Redis: on start, usage is first decayed by the time
+// elapsed since then, so restarts neither skip nor repeat any decay.
+func (fd *FrecencyDecayer) Start(ctx context.Context) error {
+	last := fd.catchUp(ctx, time.Now())
+
+	ticker := time.NewTicker(fd.interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				fd.decaySince(ctx, last, now)
+				last = now
+			case <-fd.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// catchUp decays usage by the time elapsed since the last recorded decay.
+// It returns the time the next decay is measured from.
+func (fd *FrecencyDecayer) catchUp(ctx context.Context, now time.Time) time.Time {
+	if fd.store == nil {
+		return now
+	}
+
+	last, err := fd.store.GetLastDecay(ctx)
+	if err != nil {
+		fd.logger.Warn("failed to load last frecency decay, decaying from now on",
+			logger.Error(err))
+		return now
+	}
+
+	if last.IsZero() || !last.Before(now) {
+		// First start: nothing to catch up, start measuring from now
+		fd.saveLastDecay(ctx, now)
+		return now
+	}
+
+	fd.decaySince(ctx, last, now)
+	return now
+}
+
+// decaySince decays usage by the time elapsed between two decays and records the new one
+func (fd *FrecencyDecayer) decaySince(ctx context.Context, last, now time.Time) {
+	if err := fd.Decay(ctx, now.Sub(last)); err != nil {
+		fd.logger.Error("frecency decay failed",
+			logger.Error(err))
+		return
+	}
+	fd.saveLastDecay(ctx, now)
+}
+
+// saveLastDecay records the time of the last decay in Redis (best effort)
+func (fd *FrecencyDecayer) saveLastDecay(ctx context.Context, at time.Time) {
+	if fd.store == nil {
+		return
+	}
+	if err := fd.store.SaveLastDecay(ctx, at); err != nil {
+		fd.logger.Warn("failed to save last frecency decay",
+			logger.Error(err))
+	}
+}
+
+// Stop stops the decayer
+func (fd *FrecencyDecayer) Stop() {
+	close(fd.stopCh)
+}
+
+// Decay applies the decay matching the elapsed time to global, per-client and bookmark usage
+func (fd *FrecencyDecayer) Decay(ctx context.Context, elapsed time.Duration) error {
+	factor := domain.DecayFactor(elapsed, fd.halfLife)
+
+	// Update memory index
+	bookmarks := fd.index.DecayBookmarkFrecency(factor)
+	services := fd.index.DecayFrecency(factor)
+
+	fd.logger.Debug("frecency decayed",
+		logger.Int("services", len(services)),
+		logger.Int("bookmarks", len(bookmarks)),
+		logger.Duration("elapsed", elapsed))
+
+	if fd.store == nil {
+		return nil
+	}
+
+	// Update Redis store
+	if len(services) > 0 {
+		if err := fd.store.SaveServicesMany(ctx, services); err != nil {
+			return err
+		}
+	}
+
+	if len(bookmarks) > 0 {
+		if err := fd.store.SaveBookmarksMany(ctx, bookmarks); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fd.store.DecayClientUsage(ctx, factor); err != nil {
+		return err
+	}
+
+	return nil
+}
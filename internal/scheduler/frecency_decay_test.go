@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/index"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+func TestFrecencyDecayer_Decay(t *testing.T) {
+	log := logger.New("error", false)
+	memIndex := index.NewMemoryIndex()
+	halfLife := 14 * 24 * time.Hour
+
+	memIndex.UpdateServices([]*domain.Service{
+		{ID: "used.example.com", Hostname: "used.example.com", Counter: 8, Frecency: 8},
+		{ID: "unused.example.com", Hostname: "unused.example.com"},
+	})
+	memIndex.UpdateBookmarks([]*domain.Bookmark{
+		{ID: "github", Abbr: "GitHub", Counter: 2, Frecency: 2},
+	})
+
+	decayer := NewFrecencyDecayer(nil, memIndex, log, time.Hour, halfLife)
+	if err := decayer.Decay(context.Background(), halfLife); err != nil {
+		t.Fatalf("Decay() error = %v", err)
+	}
+
+	used, _ := memIndex.GetService("used.example.com")
+	if used.Frecency != 4 {
+		t.Errorf("Expected frecency to be halved after one half-life, got %.2f", used.Frecency)
+	}
+	if used.Counter != 8 {
+		t.Errorf("Expected lifetime counter to be kept, got %d", used.Counter)
+	}
+
+	unused, _ := memIndex.GetService("unused.example.com")
+	if unused.Frecency != 0 {
+		t.Errorf("Expected unused service to stay at zero, got %.2f", unused.Frecency)
+	}
+
+	bookmark, _ := memIndex.GetBookmark("github")
+	if bookmark.Frecency != 1 {
+		t.Errorf("Expected bookmark frecency to be halved after one half-life, got %.2f", bookmark.Frecency)
+	}
+
+	if memIndex.GetLastDecay().IsZero() {
+		t.Error("Expected last decay time to be set")
+	}
+}
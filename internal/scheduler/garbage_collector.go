@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/MrSnakeDoc/jump/internal/domain"
 	"github.com/MrSnakeDoc/jump/internal/index"
 	"github.com/MrSnakeDoc/jump/internal/logger"
 	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
@@ -118,6 +119,11 @@ func (gc *GarbageCollector) collectServices(ctx context.Context, now time.Time)
 			continue
 		}
 
+		// Services managed through the API are only removed through the API
+		if service.HasSource(domain.SourceAPI) {
+			continue
+		}
+
 		// Check if service has been disabled long enough
 		if service.UpdatedAt.IsZero() {
 			continue
@@ -83,3 +83,26 @@ func TestGarbageCollector_Collect(t *testing.T) {
 		t.Error("Old disabled service was not removed")
 	}
 }
+
+func TestGarbageCollector_KeepsAPIServices(t *testing.T) {
+	memIndex := index.NewMemoryIndex()
+	memIndex.UpdateServices([]*domain.Service{
+		{
+			ID:        "adhoc.example.com",
+			Hostname:  "adhoc.example.com",
+			Name:      "adhoc",
+			Sources:   []string{domain.SourceAPI},
+			Disabled:  true,
+			UpdatedAt: time.Now().Add(-35 * 24 * time.Hour),
+		},
+	})
+
+	gc := NewGarbageCollector(nil, memIndex, logger.New("error", false), 24*time.Hour, 30*24*time.Hour)
+	if err := gc.Collect(context.Background()); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	if _, ok := memIndex.GetService("adhoc.example.com"); !ok {
+		t.Error("Disabled API service was garbage collected, want it kept until deleted through the API")
+	}
+}
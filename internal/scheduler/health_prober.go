@@ -0,0 +1,176 @@
+package scheduler
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/index"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
+)
+
+const (
+	// DefaultProbeConcurrency is the maximum number of services probed at the same time
+	DefaultProbeConcurrency = 8
+)
+
+// probeFunc checks that a service answers (TLS handshake by default)
+type probeFunc func(ctx context.Context, service *domain.Service) error
+
+// HealthProber periodically probes every active service and records its liveness
+type HealthProber struct {
+	store    *redisstore.Store
+	index    *index.MemoryIndex
+	logger   logger.Logger
+	interval time.Duration
+	jitter   time.Duration
+	probe    probeFunc
+	stopCh   chan struct{}
+}
+
+// NewHealthProber creates a new health prober
+func NewHealthProber(
+	store *redisstore.Store,
+	idx *index.MemoryIndex,
+	log logger.Logger,
+	interval time.Duration,
+	jitter time.Duration,
+	timeout time.Duration,
+) *HealthProber {
+	return &HealthProber{
+		store:    store,
+		index:    idx,
+		logger:   log,
+		interval: interval,
+		jitter:   jitter,
+		probe: func(ctx context.Context, service *domain.Service) error {
+			return domain.ValidateServiceContext(ctx, service, service.ValidationTimeout(timeout))
+		},
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Start restores the last known health from Redis and begins periodic probing.
+// The first probe round runs in the background so startup is not delayed.
+func (hp *HealthProber) Start(ctx context.Context) error {
+	hp.restore(ctx)
+
+	go func() {
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C:
+				hp.ProbeAll(ctx)
+				timer.Reset(hp.nextDelay())
+			case <-hp.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the prober
+func (hp *HealthProber) Stop() {
+	close(hp.stopCh)
+}
+
+// ProbeAll probes every active service and updates store + index
+func (hp *HealthProber) ProbeAll(ctx context.Context) []*domain.Health {
+	var services []*domain.Service
+	for _, service := range hp.index.GetAllServices() {
+		if !service.Disabled {
+			services = append(services, service)
+		}
+	}
+
+	results := make([]*domain.Health, len(services))
+	sem := make(chan struct{}, DefaultProbeConcurrency)
+	var wg sync.WaitGroup
+
+	for i, service := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = hp.probeOne(ctx, service)
+		}()
+	}
+	wg.Wait()
+
+	down := 0
+	for _, h := range results {
+		if !h.Up {
+			down++
+		}
+	}
+
+	// Update memory index
+	hp.index.UpdateHealth(results)
+
+	hp.logger.Debug("health probe completed",
+		logger.Int("probed", len(results)),
+		logger.Int("down", down))
+
+	// Update Redis store (best effort)
+	if hp.store != nil && len(results) > 0 {
+		if err := hp.store.SaveHealthMany(ctx, results); err != nil {
+			hp.logger.Warn("failed to save health to redis",
+				logger.Error(err))
+		}
+	}
+
+	return results
+}
+
+// probeOne probes a single service and records the outcome
+func (hp *HealthProber) probeOne(ctx context.Context, service *domain.Service) *domain.Health {
+	start := time.Now()
+	err := hp.probe(ctx, service)
+
+	h := &domain.Health{
+		ServiceID: service.ID,
+		Up:        err == nil,
+		Latency:   time.Since(start),
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		h.LastError = err.Error()
+	}
+	return h
+}
+
+// restore loads the last known health from Redis so requests can use it before the first probe
+func (hp *HealthProber) restore(ctx context.Context) {
+	if hp.store == nil {
+		return
+	}
+
+	results, err := hp.store.GetAllHealth(ctx)
+	if err != nil {
+		hp.logger.Warn("failed to load health from redis",
+			logger.Error(err))
+		return
+	}
+	if len(results) > 0 {
+		hp.index.UpdateHealth(results)
+		hp.logger.Info("restored service health from redis",
+			logger.Int("count", len(results)))
+	}
+}
+
+// nextDelay returns the interval plus a random jitter, so probes don't hit every service in lockstep
+func (hp *HealthProber) nextDelay() time.Duration {
+	if hp.jitter <= 0 {
+		return hp.interval
+	}
+	return hp.interval + rand.N(hp.jitter)
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/index"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+func TestHealthProber_ProbeAll(t *testing.T) {
+	log := logger.New("error", false)
+	memIndex := index.NewMemoryIndex()
+
+	memIndex.UpdateServices([]*domain.Service{
+		{ID: "up.example.com", Hostname: "up.example.com", Name: "up"},
+		{ID: "down.example.com", Hostname: "down.example.com", Name: "down"},
+		{ID: "disabled.example.com", Hostname: "disabled.example.com", Name: "disabled", Disabled: true},
+	})
+
+	prober := NewHealthProber(nil, memIndex, log, time.Minute, 0, time.Second)
+	prober.probe = func(_ context.Context, service *domain.Service) error {
+		if service.Name == "down" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	results := prober.ProbeAll(context.Background())
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 probed services (disabled skipped), got %d", len(results))
+	}
+
+	up, ok := memIndex.GetHealth("up.example.com")
+	if !ok || !up.Up || up.LastError != "" {
+		t.Errorf("Expected up.example.com to be up, got %+v", up)
+	}
+
+	down, ok := memIndex.GetHealth("down.example.com")
+	if !ok || down.Up || down.LastError != "connection refused" {
+		t.Errorf("Expected down.example.com to be down with error, got %+v", down)
+	}
+
+	if _, ok := memIndex.GetHealth("disabled.example.com"); ok {
+		t.Error("Disabled service should not be probed")
+	}
+
+	upCount, downCount := memIndex.HealthCounts()
+	if upCount != 1 || downCount != 1 {
+		t.Errorf("Expected 1 up and 1 down, got %d up and %d down", upCount, downCount)
+	}
+
+	if memIndex.GetLastProbe().IsZero() {
+		t.Error("Expected last probe time to be set")
+	}
+}
+
+func TestHealthProber_NextDelay(t *testing.T) {
+	log := logger.New("error", false)
+	prober := NewHealthProber(nil, index.NewMemoryIndex(), log, time.Minute, 10*time.Second, time.Second)
+
+	for range 100 {
+		delay := prober.nextDelay()
+		if delay < time.Minute || delay >= time.Minute+10*time.Second {
+			t.Fatalf("Delay %v outside of [1m, 1m10s)", delay)
+		}
+	}
+}
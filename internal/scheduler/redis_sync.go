@@ -8,7 +8,7 @@ import (
 	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
 )
 
-// RedisSyncer syncs services from Redis to memory index on startup
+// RedisSyncer syncs services and bookmarks from Redis to memory index on startup
 type RedisSyncer struct {
 	store  *redisstore.Store
 	index  *index.MemoryIndex
@@ -28,8 +28,16 @@ func NewRedisSyncer(
 	}
 }
 
-// Sync loads services from Redis and updates memory index
+// Sync loads services and bookmarks from Redis and updates memory index
 func (rs *RedisSyncer) Sync(ctx context.Context) error {
+	if err := rs.syncServices(ctx); err != nil {
+		return err
+	}
+	return rs.syncBookmarks(ctx)
+}
+
+// syncServices loads services from Redis into the memory index
+func (rs *RedisSyncer) syncServices(ctx context.Context) error {
 	rs.logger.Info("syncing services from redis to memory")
 
 	services, err := rs.store.GetAllServices(ctx)
@@ -42,6 +50,13 @@ func (rs *RedisSyncer) Sync(ctx context.Context) error {
 		return nil
 	}
 
+	// Services saved before frecency existed start from their lifetime counter
+	for _, service := range services {
+		if service.Frecency == 0 && service.Counter > 0 && service.LastUsedAt.IsZero() {
+			service.Frecency = float64(service.Counter)
+		}
+	}
+
 	rs.index.UpdateServices(services)
 
 	rs.logger.Info("synced services from redis",
@@ -49,3 +64,23 @@ func (rs *RedisSyncer) Sync(ctx context.Context) error {
 
 	return nil
 }
+
+// syncBookmarks loads bookmarks from Redis into the memory index
+func (rs *RedisSyncer) syncBookmarks(ctx context.Context) error {
+	bookmarks, err := rs.store.GetAllBookmarks(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(bookmarks) == 0 {
+		rs.logger.Info("no bookmarks found in redis")
+		return nil
+	}
+
+	rs.index.UpdateBookmarks(bookmarks)
+
+	rs.logger.Info("synced bookmarks from redis",
+		logger.Int("count", len(bookmarks)))
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/index"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	"github.com/MrSnakeDoc/jump/internal/sources"
+	redisstore "github.com/MrSnakeDoc/jump/internal/store/redis"
+)
+
+// ServiceReloader handles periodic reloading of services from the enabled sources
+type ServiceReloader struct {
+	reconciler    *sources.Reconciler
+	store         *redisstore.Store
+	index         *index.MemoryIndex
+	logger        logger.Logger
+	interval      time.Duration
+	stopCh        chan struct{}
+	manualTrigger chan struct{}
+}
+
+// NewServiceReloader creates a new service reloader
+func NewServiceReloader(
+	reconciler *sources.Reconciler,
+	store *redisstore.Store,
+	idx *index.MemoryIndex,
+	log logger.Logger,
+	interval time.Duration,
+	manualTrigger chan struct{},
+) *ServiceReloader {
+	return &ServiceReloader{
+		reconciler:    reconciler,
+		store:         store,
+		index:         idx,
+		logger:        log,
+		interval:      interval,
+		stopCh:        make(chan struct{}),
+		manualTrigger: manualTrigger,
+	}
+}
+
+// Start begins the periodic reload process
+func (sr *ServiceReloader) Start(ctx context.Context) error {
+	// Load immediately on start
+	if err := sr.Reload(ctx); err != nil {
+		return fmt.Errorf("initial reload failed: %w", err)
+	}
+
+	// Start periodic reload
+	ticker := time.NewTicker(sr.interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := sr.Reload(ctx); err != nil {
+					sr.logger.Error("failed to reload services",
+						logger.Error(err))
+				}
+			case <-sr.manualTrigger:
+				sr.logger.Info("manual reload triggered")
+				if err := sr.Reload(ctx); err != nil {
+					sr.logger.Error("failed to reload services",
+						logger.Error(err))
+				}
+			case <-sr.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the reloader
+func (sr *ServiceReloader) Stop() {
+	close(sr.stopCh)
+}
+
+// Reload loads services from every source and updates store + index
+func (sr *ServiceReloader) Reload(ctx context.Context) error {
+	sr.logger.Info("reloading services from sources")
+
+	services, err := sr.reconciler.Services(ctx, sr.index.GetAllServices())
+	if err != nil {
+		return err
+	}
+
+	// Update memory index
+	sr.index.UpdateServices(services)
+
+	// Update Redis store (best effort)
+	if sr.store != nil {
+		if err := sr.store.SaveServicesMany(ctx, services); err != nil {
+			sr.logger.Warn("failed to save services to redis",
+				logger.Error(err))
+			// Don't fail - memory index is the primary source
+		} else {
+			sr.logger.Info("services saved to redis")
+		}
+	}
+
+	return nil
+}
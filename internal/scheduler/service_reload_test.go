@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/index"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	"github.com/MrSnakeDoc/jump/internal/sources"
+	"github.com/MrSnakeDoc/jump/internal/sources/homepage"
+)
+
+func TestServiceReloader_KeepsAPIServices(t *testing.T) {
+	servicesFile := filepath.Join(t.TempDir(), "services.yaml")
+	yamlContent := `---
+- Media:
+    - Jellyfin:
+        href: https://jellyfin.domain.ext
+    - Sonarr:
+        href: https://sonarr.domain.ext
+`
+	if err := os.WriteFile(servicesFile, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("Failed to create test YAML file: %v", err)
+	}
+
+	memIndex := index.NewMemoryIndex()
+	memIndex.UpdateServices([]*domain.Service{
+		// Created through the API, unknown to Homepage
+		{ID: "adhoc.domain.ext", Hostname: "adhoc.domain.ext", Name: "adhoc", Sources: []string{domain.SourceAPI}},
+		// Homepage service edited through the API
+		{ID: "jellyfin.domain.ext", Hostname: "jellyfin.domain.ext", Name: "jelly", Pinned: true, Sources: []string{domain.SourceHomepage, domain.SourceAPI}},
+		// Homepage service removed from services.yaml
+		{ID: "radarr.domain.ext", Hostname: "radarr.domain.ext", Name: "radarr", Sources: []string{domain.SourceHomepage}},
+	})
+
+	reloader := newHomepageServiceReloader(servicesFile, memIndex)
+	if err := reloader.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	adhoc, ok := memIndex.GetService("adhoc.domain.ext")
+	if !ok || adhoc.Disabled {
+		t.Fatalf("API service = %+v, want it kept and enabled", adhoc)
+	}
+
+	jellyfin, ok := memIndex.GetService("jellyfin.domain.ext")
+	if !ok {
+		t.Fatal("API-managed Homepage service was dropped")
+	}
+	if jellyfin.Name != "jelly" || !jellyfin.Pinned {
+		t.Errorf("API edits were overwritten by the reload: name %q, pinned %v", jellyfin.Name, jellyfin.Pinned)
+	}
+	if !jellyfin.HasSource(domain.SourceHomepage) || !jellyfin.HasSource(domain.SourceAPI) {
+		t.Errorf("Sources = %v, want homepage and api", jellyfin.Sources)
+	}
+
+	if sonarr, ok := memIndex.GetService("sonarr.domain.ext"); !ok || sonarr.Disabled {
+		t.Error("Homepage service was not loaded")
+	}
+
+	if radarr, ok := memIndex.GetService("radarr.domain.ext"); !ok || !radarr.Disabled {
+		t.Error("Service removed from Homepage was not disabled")
+	}
+}
+
+func TestServiceReloader_ReleasesRemovedAPIServices(t *testing.T) {
+	servicesFile := filepath.Join(t.TempDir(), "services.yaml")
+	yamlContent := `---
+- Media:
+    - Sonarr:
+        href: https://sonarr.domain.ext
+`
+	if err := os.WriteFile(servicesFile, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("Failed to create test YAML file: %v", err)
+	}
+
+	memIndex := index.NewMemoryIndex()
+	memIndex.UpdateServices([]*domain.Service{
+		{ID: "jellyfin.domain.ext", Hostname: "jellyfin.domain.ext", Name: "jellyfin", Sources: []string{domain.SourceHomepage, domain.SourceAPI}},
+	})
+
+	reloader := newHomepageServiceReloader(servicesFile, memIndex)
+	if err := reloader.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	jellyfin, ok := memIndex.GetService("jellyfin.domain.ext")
+	if !ok || jellyfin.Disabled {
+		t.Fatal("API-managed service was disabled when Homepage dropped it")
+	}
+	if jellyfin.HasSource(domain.SourceHomepage) {
+		t.Errorf("Sources = %v, want homepage removed", jellyfin.Sources)
+	}
+}
+
+// newHomepageServiceReloader creates a reloader for a Homepage services file, without Redis
+func newHomepageServiceReloader(servicesFile string, memIndex *index.MemoryIndex) *ServiceReloader {
+	log := logger.New("error", false)
+	source := homepage.NewSource(servicesFile, "", domain.BookmarkPolicy{}, log)
+	reconciler := sources.NewReconciler([]sources.Source{source}, "", log)
+	return NewServiceReloader(reconciler, nil, memIndex, log, time.Hour, nil)
+}
@@ -4,22 +4,33 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/logger"
 )
 
 // BookmarkMapper converts Homepage bookmark config to domain bookmarks
-type BookmarkMapper struct{}
+type BookmarkMapper struct {
+	policy domain.BookmarkPolicy
+	logger logger.Logger
+}
 
-// NewBookmarkMapper creates a new bookmark mapper
-func NewBookmarkMapper() *BookmarkMapper {
-	return &BookmarkMapper{}
+// NewBookmarkMapper creates a new bookmark mapper enforcing a URL policy
+func NewBookmarkMapper(policy domain.BookmarkPolicy, log logger.Logger) *BookmarkMapper {
+	return &BookmarkMapper{
+		policy: policy,
+		logger: log,
+	}
 }
 
-// MapBookmarks converts BookmarksConfig to domain.Bookmark slice
-func (m *BookmarkMapper) MapBookmarks(config BookmarksConfig) ([]*domain.Bookmark, error) {
+// MapBookmarks converts BookmarksConfig to domain.Bookmark slice.
+// Bookmarks violating the URL policy are logged and skipped;
+// their number is returned along with the mapped bookmarks.
+func (m *BookmarkMapper) MapBookmarks(config BookmarksConfig) ([]*domain.Bookmark, int, error) {
 	bookmarks := make([]*domain.Bookmark, 0)
+	rejected := 0
 	now := time.Now()
 
 	for _, category := range config {
@@ -33,16 +44,28 @@ func (m *BookmarkMapper) MapBookmarks(config BookmarksConfig) ([]*domain.Bookmar
 					entry := entryList[0] // Take the first (and only) entry
 
 					// Use Abbr if present, otherwise use bookmark name
-					abbr := entry.Abbr
+					abbr := strings.TrimSpace(entry.Abbr)
 					if abbr == "" {
-						abbr = bookmarkName
+						abbr = strings.TrimSpace(bookmarkName)
 					}
+					abbrs := bookmarkAbbrs(abbr, entry.Abbrs)
 
 					// Skip if no href
 					if entry.Href == "" {
 						continue
 					}
 
+					// Reject URLs the policy does not allow (javascript:, data:, unknown domains)
+					if err := m.policy.Check(entry.Href); err != nil {
+						m.logger.Warn("rejected bookmark",
+							logger.String("category", categoryName),
+							logger.String("name", bookmarkName),
+							logger.String("url", entry.Href),
+							logger.Error(err))
+						rejected++
+						continue
+					}
+
 					// Generate ID from URL (stable identifier)
 					// Use a hash of the URL to create a short, consistent ID
 					id := generateBookmarkID(entry.Href)
@@ -50,8 +73,11 @@ func (m *BookmarkMapper) MapBookmarks(config BookmarksConfig) ([]*domain.Bookmar
 					bookmark := &domain.Bookmark{
 						ID:        id,
 						Abbr:      abbr,
+						Abbrs:     abbrs,
 						URL:       entry.Href,
-						Sources:   []string{"homepage"},
+						Name:      strings.TrimSpace(bookmarkName),
+						Category:  strings.TrimSpace(categoryName),
+						Sources:   []string{domain.SourceHomepage},
 						CreatedAt: now,
 						UpdatedAt: now,
 						Disabled:  false,
@@ -60,17 +86,14 @@ func (m *BookmarkMapper) MapBookmarks(config BookmarksConfig) ([]*domain.Bookmar
 					bookmarks = append(bookmarks, bookmark)
 				}
 			}
-
-			// Log category for debugging
-			_ = categoryName // unused but helps understand structure
 		}
 	}
 
 	if len(bookmarks) == 0 {
-		return nil, fmt.Errorf("no valid bookmarks found in config")
+		return nil, rejected, fmt.Errorf("no valid bookmarks found in config")
 	}
 
-	return bookmarks, nil
+	return bookmarks, rejected, nil
 }
 
 // generateBookmarkID creates a stable ID from a URL using SHA-256 hash
@@ -82,3 +105,20 @@ func generateBookmarkID(url string) string {
 	// Take first 16 characters of hex encoding (sufficient for uniqueness)
 	return hex.EncodeToString(hash[:])[:16]
 }
+
+// bookmarkAbbrs returns the primary abbreviation followed by the extra ones,
+// without blanks or case-insensitive duplicates
+func bookmarkAbbrs(primary string, extra []string) []string {
+	abbrs := make([]string, 0, 1+len(extra))
+	seen := make(map[string]bool, 1+len(extra))
+	for _, abbr := range append([]string{primary}, extra...) {
+		abbr = strings.TrimSpace(abbr)
+		key := strings.ToLower(abbr)
+		if abbr == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		abbrs = append(abbrs, abbr)
+	}
+	return abbrs
+}
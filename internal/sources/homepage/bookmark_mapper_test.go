@@ -0,0 +1,91 @@
+package homepage
+
+import (
+	"testing"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+func TestBookmarkMapperMapBookmarksPolicy(t *testing.T) {
+	config := BookmarksConfig{
+		{
+			"Developer": []map[string][]BookmarkEntry{
+				{"GitHub": {{Abbr: "GH", Href: "https://github.com"}}},
+				{"Script": {{Abbr: "JS", Href: "javascript:alert(1)"}}},
+				{"Inline": {{Abbr: "DT", Href: "data:text/html,hello"}}},
+				{"Tracker": {{Abbr: "TR", Href: "https://tracker.ads.example"}}},
+			},
+		},
+	}
+
+	policy := domain.BookmarkPolicy{DeniedDomains: []string{"ads.example"}}
+	mapper := NewBookmarkMapper(policy, logger.New("error", false))
+
+	bookmarks, rejected, err := mapper.MapBookmarks(config)
+	if err != nil {
+		t.Fatalf("MapBookmarks() error = %v", err)
+	}
+
+	if len(bookmarks) != 1 || bookmarks[0].URL != "https://github.com" {
+		t.Errorf("MapBookmarks() = %d bookmarks, want only GitHub", len(bookmarks))
+	}
+	if rejected != 3 {
+		t.Errorf("MapBookmarks() rejected = %d, want 3", rejected)
+	}
+}
+
+func TestBookmarkMapperMapBookmarksAllRejected(t *testing.T) {
+	config := BookmarksConfig{
+		{
+			"Developer": []map[string][]BookmarkEntry{
+				{"Script": {{Href: "javascript:alert(1)"}}},
+			},
+		},
+	}
+
+	mapper := NewBookmarkMapper(domain.BookmarkPolicy{}, logger.New("error", false))
+
+	bookmarks, rejected, err := mapper.MapBookmarks(config)
+	if err == nil {
+		t.Error("MapBookmarks() should return error when every bookmark is rejected")
+	}
+	if bookmarks != nil || rejected != 1 {
+		t.Errorf("MapBookmarks() = %v bookmarks, %d rejected; want nil, 1", bookmarks, rejected)
+	}
+}
+
+func TestBookmarkMapperMapBookmarksMetadata(t *testing.T) {
+	config := BookmarksConfig{
+		{
+			"Developer": []map[string][]BookmarkEntry{
+				{"Docker Hub": {{Abbr: "DH", Abbrs: []string{"images", "dh", " "}, Href: "https://hub.docker.com"}}},
+				{"GitHub": {{Href: "https://github.com"}}},
+			},
+		},
+	}
+
+	mapper := NewBookmarkMapper(domain.BookmarkPolicy{}, logger.New("error", false))
+	bookmarks, _, err := mapper.MapBookmarks(config)
+	if err != nil {
+		t.Fatalf("MapBookmarks() error = %v", err)
+	}
+
+	byURL := make(map[string]*domain.Bookmark, len(bookmarks))
+	for _, bm := range bookmarks {
+		byURL[bm.URL] = bm
+	}
+
+	hub := byURL["https://hub.docker.com"]
+	if hub.Name != "Docker Hub" || hub.Category != "Developer" || hub.Abbr != "DH" {
+		t.Errorf("unexpected Docker Hub bookmark: %+v", hub)
+	}
+	if len(hub.Abbrs) != 2 || hub.Abbrs[0] != "DH" || hub.Abbrs[1] != "images" {
+		t.Errorf("Abbrs = %v, want [DH images]", hub.Abbrs)
+	}
+
+	github := byURL["https://github.com"]
+	if github.Abbr != "GitHub" || len(github.Abbrs) != 1 {
+		t.Errorf("bookmark without abbr should use its name: %+v", github)
+	}
+}
@@ -5,6 +5,9 @@ type BookmarkEntry struct {
 	Icon string `yaml:"icon"`
 	Abbr string `yaml:"abbr"`
 	Href string `yaml:"href"`
+
+	// Abbrs are extra abbreviations for Jump (ignored by Homepage)
+	Abbrs []string `yaml:"abbrs"`
 }
 
 // BookmarkCategory represents a category with its bookmarks
@@ -2,7 +2,6 @@ package homepage
 
 import (
 	"fmt"
-	"net/url"
 	"strings"
 	"time"
 
@@ -25,41 +24,26 @@ func (m *Mapper) MapServices(config ServicesConfig) ([]*domain.Service, error) {
 	// Iterate through groups
 	for _, groupMap := range config {
 		for groupName, servicesList := range groupMap {
-			_ = groupName // Group name available if needed
-
 			// Iterate through services in this group
 			for _, serviceMap := range servicesList {
 				for serviceName, props := range serviceMap {
-					_ = serviceName // Service name available if needed
-
 					// Skip services without href
 					if props.Href == "" {
 						continue
 					}
 
-					// Parse URL to extract hostname
-					parsedURL, err := url.Parse(props.Href)
+					// Parse URL to extract hostname, port, path and name
+					service, err := domain.NewService(props.Href)
 					if err != nil {
-						// Skip invalid URLs
-						continue
-					}
-
-					hostname := parsedURL.Hostname()
-					if hostname == "" {
+						// Skip invalid or non-web URLs
 						continue
 					}
 
-					// Extract service name from first DNS label (subdomain)
-					name := extractServiceName(hostname)
-
-					service := &domain.Service{
-						ID:         hostname,
-						Hostname:   hostname,
-						Name:       name,
-						Sources:    []string{"homepage"},
-						LastSeenAt: now,
-						Counter:    0,
-					}
+					service.DisplayName = strings.TrimSpace(serviceName)
+					service.Description = strings.TrimSpace(props.Description)
+					service.Group = strings.TrimSpace(groupName)
+					service.Sources = []string{domain.SourceHomepage}
+					service.LastSeenAt = now
 
 					services = append(services, service)
 				}
@@ -73,13 +57,3 @@ func (m *Mapper) MapServices(config ServicesConfig) ([]*domain.Service, error) {
 
 	return services, nil
 }
-
-// extractServiceName extracts the first DNS label as service name
-// Example: "jellyfin.domain.ext" -> "jellyfin"
-func extractServiceName(hostname string) string {
-	parts := strings.Split(hostname, ".")
-	if len(parts) > 0 {
-		return parts[0]
-	}
-	return hostname
-}
@@ -44,6 +44,15 @@ func TestMapperMapServices(t *testing.T) {
 			if svc.Name != "adguard" {
 				t.Errorf("service Name = %v, want adguard", svc.Name)
 			}
+			if svc.DisplayName != "AdGuard Home" {
+				t.Errorf("service DisplayName = %v, want AdGuard Home", svc.DisplayName)
+			}
+			if svc.Description != "Network-wide ads blocking" {
+				t.Errorf("service Description = %v, want Network-wide ads blocking", svc.Description)
+			}
+			if svc.Group != "Infrastructure" {
+				t.Errorf("service Group = %v, want Infrastructure", svc.Group)
+			}
 		}
 	}
 	if !found {
@@ -126,3 +135,49 @@ func TestMapperMapServicesMultipleGroups(t *testing.T) {
 		t.Errorf("MapServices() returned %v services, want 2", len(services))
 	}
 }
+
+func TestMapperMapServicesFullURL(t *testing.T) {
+	config := ServicesConfig{
+		{
+			"NAS": []map[string]ServiceProps{
+				{"DSM": {Href: "https://nas.lan:5001"}},
+				{"Files": {Href: "https://nas.lan:5001/files/"}},
+				{"Printer": {Href: "http://printer.lan"}},
+				{"Grafana": {Href: "https://Domain.ext/grafana#home"}},
+				{"Share": {Href: "smb://nas.lan/share"}},
+			},
+		},
+	}
+
+	services, err := NewMapper().MapServices(config)
+	if err != nil {
+		t.Fatalf("MapServices() error = %v", err)
+	}
+
+	want := map[string]struct {
+		url  string
+		name string
+	}{
+		"nas.lan:5001":       {"https://nas.lan:5001", "nas"},
+		"nas.lan:5001/files": {"https://nas.lan:5001/files/", "files"},
+		"http://printer.lan": {"http://printer.lan", "printer"},
+		"domain.ext/grafana": {"https://domain.ext/grafana", "grafana"},
+	}
+
+	if len(services) != len(want) {
+		t.Fatalf("MapServices() returned %d services, want %d (non-web schemes skipped)", len(services), len(want))
+	}
+	for _, svc := range services {
+		expected, ok := want[svc.ID]
+		if !ok {
+			t.Errorf("unexpected service ID %q", svc.ID)
+			continue
+		}
+		if svc.URL != expected.url {
+			t.Errorf("service %s URL = %q, want %q", svc.ID, svc.URL, expected.url)
+		}
+		if svc.Name != expected.name {
+			t.Errorf("service %s Name = %q, want %q", svc.ID, svc.Name, expected.name)
+		}
+	}
+}
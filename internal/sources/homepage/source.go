@@ -0,0 +1,87 @@
+package homepage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MrSnakeDoc/jump/internal/config"
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	"github.com/MrSnakeDoc/jump/internal/sources"
+)
+
+func init() { sources.Register(domain.SourceHomepage, newSourceFromConfig) }
+
+// Source lists the services of Homepage's services.yaml and the bookmarks of its bookmarks.yaml
+type Source struct {
+	loader         *Loader
+	mapper         *Mapper
+	bookmarkLoader *BookmarkLoader // nil when no bookmarks file is configured
+	bookmarkMapper *BookmarkMapper
+}
+
+// NewSource creates a Homepage source. bookmarkFile is optional.
+func NewSource(serviceFile, bookmarkFile string, policy domain.BookmarkPolicy, log logger.Logger) *Source {
+	source := &Source{
+		loader: NewLoader(serviceFile),
+		mapper: NewMapper(),
+	}
+	if bookmarkFile != "" {
+		source.bookmarkLoader = NewBookmarkLoader(bookmarkFile)
+		source.bookmarkMapper = NewBookmarkMapper(policy, log)
+	}
+	return source
+}
+
+// newSourceFromConfig builds the Homepage source from JUMP_SERVICE_FILE and JUMP_BOOKMARK_FILE
+func newSourceFromConfig(cfg *config.Config, log logger.Logger) (sources.Source, error) {
+	return NewSource(cfg.ServiceFile, cfg.BookmarkFile, domain.BookmarkPolicy{
+		Schemes:        cfg.BookmarkSchemes,
+		AllowedDomains: cfg.BookmarkAllowedDomains,
+		DeniedDomains:  cfg.BookmarkDeniedDomains,
+	}, log), nil
+}
+
+// Name returns the provenance of Homepage entries
+func (s *Source) Name() string {
+	return domain.SourceHomepage
+}
+
+// Services loads and maps services.yaml
+func (s *Source) Services(_ context.Context) ([]*domain.Service, error) {
+	servicesConfig, err := s.loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load services: %w", err)
+	}
+
+	services, err := s.mapper.MapServices(servicesConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map services: %w", err)
+	}
+
+	return services, nil
+}
+
+// Bookmarks loads and maps bookmarks.yaml
+func (s *Source) Bookmarks(_ context.Context) ([]*domain.Bookmark, int, error) {
+	if s.bookmarkLoader == nil {
+		return nil, 0, sources.ErrNoBookmarks
+	}
+
+	bookmarksConfig, err := s.bookmarkLoader.Load()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load bookmarks: %w", err)
+	}
+
+	bookmarks, rejected, err := s.bookmarkMapper.MapBookmarks(bookmarksConfig)
+	if err != nil {
+		return nil, rejected, fmt.Errorf("failed to map bookmarks: %w", err)
+	}
+
+	return bookmarks, rejected, nil
+}
+
+// HasBookmarks reports whether a bookmarks file is configured
+func (s *Source) HasBookmarks() bool {
+	return s.bookmarkLoader != nil
+}
@@ -0,0 +1,49 @@
+package homepage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	"github.com/MrSnakeDoc/jump/internal/sources"
+)
+
+func TestSource(t *testing.T) {
+	servicesPath := filepath.Join(t.TempDir(), "services.yaml")
+	yamlContent := `---
+- Media:
+    - Jellyfin:
+        href: https://jellyfin.domain.ext
+`
+	if err := os.WriteFile(servicesPath, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("Failed to create test YAML file: %v", err)
+	}
+
+	source := NewSource(servicesPath, "", domain.BookmarkPolicy{}, logger.New("error", false))
+
+	services, err := source.Services(context.Background())
+	if err != nil {
+		t.Fatalf("Services() error = %v", err)
+	}
+	if len(services) != 1 || !services[0].HasSource(domain.SourceHomepage) {
+		t.Errorf("Services() = %+v, want one Homepage service", services)
+	}
+
+	if source.HasBookmarks() {
+		t.Error("HasBookmarks() = true without a bookmarks file")
+	}
+	if _, _, err := source.Bookmarks(context.Background()); !errors.Is(err, sources.ErrNoBookmarks) {
+		t.Errorf("Bookmarks() error = %v, want ErrNoBookmarks", err)
+	}
+}
+
+func TestSourceRegistered(t *testing.T) {
+	if !slices.Contains(sources.Available(), domain.SourceHomepage) {
+		t.Errorf("Available() = %v, want homepage registered", sources.Available())
+	}
+}
@@ -0,0 +1,45 @@
+package overlay
+
+import (
+	"strings"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+)
+
+// Apply merges the overlay into services and drops the excluded ones
+func (c Config) Apply(services []*domain.Service) []*domain.Service {
+	result := make([]*domain.Service, 0, len(services))
+
+	for _, service := range services {
+		settings, ok := c.lookup(service)
+		if !ok {
+			result = append(result, service)
+			continue
+		}
+
+		if settings.Exclude {
+			continue
+		}
+
+		service.Aliases = settings.Aliases
+		service.Boost = settings.Boost
+		service.Hidden = settings.Hidden
+		service.Pinned = settings.Pinned
+		service.TLSTimeout = settings.TLSTimeout
+		service.RedirectURL = settings.RedirectURL
+
+		result = append(result, service)
+	}
+
+	return result
+}
+
+// lookup finds the settings of a service by ID first ("domain.ext/grafana", "nas.lan:5001"),
+// then by hostname
+func (c Config) lookup(service *domain.Service) (ServiceOverlay, bool) {
+	if settings, ok := c[strings.ToLower(service.ID)]; ok {
+		return settings, true
+	}
+	settings, ok := c[strings.ToLower(service.Hostname)]
+	return settings, ok
+}
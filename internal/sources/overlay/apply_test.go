@@ -0,0 +1,79 @@
+package overlay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+)
+
+func TestConfigApply(t *testing.T) {
+	services := []*domain.Service{
+		{ID: "adguard.domain.ext", Hostname: "adguard.domain.ext", Name: "adguard"},
+		{ID: "traefik.domain.ext", Hostname: "traefik.domain.ext", Name: "traefik"},
+		{ID: "old.domain.ext", Hostname: "old.domain.ext", Name: "old"},
+		{ID: "jellyfin.domain.ext", Hostname: "jellyfin.domain.ext", Name: "jellyfin"},
+	}
+
+	config := Config{
+		"adguard.domain.ext": {
+			Aliases:     []string{"dns"},
+			Boost:       20,
+			TLSTimeout:  2 * time.Second,
+			RedirectURL: "https://adguard.domain.ext/login.html",
+		},
+		"traefik.domain.ext": {Hidden: true},
+		"old.domain.ext":     {Exclude: true},
+	}
+
+	result := config.Apply(services)
+
+	if len(result) != 3 {
+		t.Fatalf("Apply() returned %d services, want 3 (excluded dropped)", len(result))
+	}
+
+	byID := make(map[string]*domain.Service, len(result))
+	for _, service := range result {
+		byID[service.ID] = service
+	}
+
+	if _, ok := byID["old.domain.ext"]; ok {
+		t.Error("excluded service should be dropped")
+	}
+
+	adguard := byID["adguard.domain.ext"]
+	if len(adguard.Aliases) != 1 || adguard.Boost != 20 || adguard.TLSTimeout != 2*time.Second ||
+		adguard.RedirectURL != "https://adguard.domain.ext/login.html" {
+		t.Errorf("overlay not applied to adguard: %+v", adguard)
+	}
+
+	if !byID["traefik.domain.ext"].Hidden {
+		t.Error("traefik should be hidden")
+	}
+
+	jellyfin := byID["jellyfin.domain.ext"]
+	if jellyfin.Boost != 0 || jellyfin.Hidden || len(jellyfin.Aliases) != 0 {
+		t.Errorf("service without overlay should be untouched: %+v", jellyfin)
+	}
+}
+
+func TestConfigApply_ServiceID(t *testing.T) {
+	services := []*domain.Service{
+		{ID: "domain.ext/grafana", Hostname: "domain.ext", Name: "grafana"},
+		{ID: "domain.ext/prometheus", Hostname: "domain.ext", Name: "prometheus"},
+	}
+
+	config := Config{
+		"domain.ext/grafana": {Boost: 10},
+		"domain.ext":         {Hidden: true},
+	}
+
+	result := config.Apply(services)
+
+	if result[0].Boost != 10 || result[0].Hidden {
+		t.Errorf("ID overlay should win over the hostname one: %+v", result[0])
+	}
+	if !result[1].Hidden {
+		t.Errorf("hostname overlay should apply to every service on the host: %+v", result[1])
+	}
+}
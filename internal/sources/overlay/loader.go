@@ -0,0 +1,65 @@
+package overlay
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Loader handles loading and parsing of the Jump overlay file
+type Loader struct {
+	filePath string
+}
+
+// NewLoader creates a new overlay loader
+func NewLoader(filePath string) *Loader {
+	return &Loader{
+		filePath: filePath,
+	}
+}
+
+// Load reads, parses and validates the overlay file
+func (l *Loader) Load() (Config, error) {
+	data, err := os.ReadFile(l.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay file: %w", err)
+	}
+
+	var raw Config
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse overlay yaml: %w", err)
+	}
+
+	// Normalize hostnames so lookups are case-insensitive
+	config := make(Config, len(raw))
+	for hostname, settings := range raw {
+		if err := validate(settings); err != nil {
+			return nil, fmt.Errorf("invalid overlay for %s: %w", hostname, err)
+		}
+		config[strings.ToLower(hostname)] = settings
+	}
+
+	return config, nil
+}
+
+// validate checks the settings of a single service
+func validate(settings ServiceOverlay) error {
+	if settings.TLSTimeout < 0 {
+		return fmt.Errorf("tls_timeout must not be negative")
+	}
+
+	if settings.RedirectURL != "" {
+		u, err := url.Parse(settings.RedirectURL)
+		if err != nil {
+			return fmt.Errorf("invalid redirect_url: %w", err)
+		}
+		if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("redirect_url must be an absolute http(s) URL")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package overlay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeOverlay(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jump.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write overlay: %v", err)
+	}
+	return path
+}
+
+func TestLoaderLoad(t *testing.T) {
+	path := writeOverlay(t, `
+AdGuard.domain.ext:
+  aliases: [dns, adblock]
+  boost: 20
+  tls_timeout: 2s
+  redirect_url: https://adguard.domain.ext/login.html
+traefik.domain.ext:
+  hidden: true
+old.domain.ext:
+  exclude: true
+`)
+
+	config, err := NewLoader(path).Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(config) != 3 {
+		t.Fatalf("Load() returned %d entries, want 3", len(config))
+	}
+
+	adguard, ok := config["adguard.domain.ext"]
+	if !ok {
+		t.Fatal("expected hostname keys to be lowercased")
+	}
+	if len(adguard.Aliases) != 2 || adguard.Aliases[0] != "dns" {
+		t.Errorf("Aliases = %v, want [dns adblock]", adguard.Aliases)
+	}
+	if adguard.Boost != 20 {
+		t.Errorf("Boost = %v, want 20", adguard.Boost)
+	}
+	if adguard.TLSTimeout != 2*time.Second {
+		t.Errorf("TLSTimeout = %v, want 2s", adguard.TLSTimeout)
+	}
+	if adguard.RedirectURL != "https://adguard.domain.ext/login.html" {
+		t.Errorf("RedirectURL = %q", adguard.RedirectURL)
+	}
+
+	if !config["traefik.domain.ext"].Hidden {
+		t.Error("expected traefik to be hidden")
+	}
+	if !config["old.domain.ext"].Exclude {
+		t.Error("expected old to be excluded")
+	}
+}
+
+func TestLoaderLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid yaml", "adguard.domain.ext: [unclosed"},
+		{"relative redirect", "adguard.domain.ext:\n  redirect_url: /login\n"},
+		{"javascript redirect", "adguard.domain.ext:\n  redirect_url: javascript:alert(1)\n"},
+		{"negative timeout", "adguard.domain.ext:\n  tls_timeout: -1s\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLoader(writeOverlay(t, tt.content)).Load(); err == nil {
+				t.Error("Load() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoaderLoadMissingFile(t *testing.T) {
+	if _, err := NewLoader(filepath.Join(t.TempDir(), "missing.yaml")).Load(); err == nil {
+		t.Error("Load() expected error for missing file, got nil")
+	}
+}
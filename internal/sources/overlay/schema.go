@@ -0,0 +1,24 @@
+package overlay
+
+import "time"
+
+// Config represents the overlay file: Jump-specific settings keyed by service ID
+// or hostname (a hostname key applies to every service on that host)
+//
+//	adguard.domain.ext:
+//	  aliases: [dns, adblock]
+//	  boost: 20
+//	  tls_timeout: 2s
+//	  redirect_url: https://adguard.domain.ext/login.html
+type Config map[string]ServiceOverlay
+
+// ServiceOverlay contains the settings applied to a single service
+type ServiceOverlay struct {
+	Aliases     []string      `yaml:"aliases,omitempty"`
+	Boost       float64       `yaml:"boost,omitempty"`
+	Hidden      bool          `yaml:"hidden,omitempty"`
+	Pinned      bool          `yaml:"pinned,omitempty"`
+	Exclude     bool          `yaml:"exclude,omitempty"`
+	TLSTimeout  time.Duration `yaml:"tls_timeout,omitempty"`
+	RedirectURL string        `yaml:"redirect_url,omitempty"`
+}
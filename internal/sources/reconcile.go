@@ -0,0 +1,335 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+	"github.com/MrSnakeDoc/jump/internal/sources/overlay"
+)
+
+// Reconciler merges what the sources list with the entries Jump already knows:
+//   - entries are merged by ID, the first source listing an entry defines it,
+//     the others only add their name to its Sources
+//   - usage learned so far is kept
+//   - an entry is disabled only when every source that owned it has dropped it;
+//     sources that failed to load keep their entries as they are, sources that
+//     are no longer enabled count as having dropped theirs
+//   - services managed through the API keep their settings and are never disabled
+type Reconciler struct {
+	sources []Source
+	overlay *overlay.Loader // nil when no overlay file is configured
+	logger  logger.Logger
+}
+
+// NewReconciler creates a reconciler for the enabled sources, in order of precedence
+func NewReconciler(sources []Source, overlayFile string, log logger.Logger) *Reconciler {
+	var overlayLoader *overlay.Loader
+	if overlayFile != "" {
+		overlayLoader = overlay.NewLoader(overlayFile)
+	}
+
+	return &Reconciler{
+		sources: sources,
+		overlay: overlayLoader,
+		logger:  log,
+	}
+}
+
+// HasBookmarks reports whether any source lists bookmarks
+func (r *Reconciler) HasBookmarks() bool {
+	for _, source := range r.sources {
+		if source.HasBookmarks() {
+			return true
+		}
+	}
+	return false
+}
+
+// Services loads the services of every source and reconciles them with the existing ones.
+// It returns the full list to index: listed, still owned and newly disabled services.
+func (r *Reconciler) Services(ctx context.Context, existing []*domain.Service) ([]*domain.Service, error) {
+	listed, failed, _, err := collect(r, "services", serviceFields, func(source Source) ([]*domain.Service, int, error) {
+		services, err := source.Services(ctx)
+		return services, 0, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Merge Jump-specific settings from the overlay file
+	if r.overlay != nil {
+		overlayConfig, err := r.overlay.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load overlay: %w", err)
+		}
+		listed = overlayConfig.Apply(listed)
+		r.logger.Info("applied overlay",
+			logger.Int("entries", len(overlayConfig)),
+			logger.Int("count", len(listed)))
+	}
+
+	// Services managed through the API are kept as they are,
+	// the sources only record whether they list them too
+	managed := func(svc *domain.Service) bool { return svc.HasSource(domain.SourceAPI) }
+
+	result, disabled, kept := reconcile(listed, existing, failed, serviceFields, managed)
+
+	if disabled > 0 {
+		r.logger.Info("marking removed services as disabled",
+			logger.Int("count", disabled))
+	}
+	if kept > 0 {
+		r.logger.Info("kept services managed through the api",
+			logger.Int("count", kept))
+	}
+
+	return result, nil
+}
+
+// Bookmarks loads the bookmarks of every source and reconciles them with the existing ones.
+// It returns the full list to index and how many bookmarks the bookmark policy rejected.
+func (r *Reconciler) Bookmarks(ctx context.Context, existing []*domain.Bookmark) ([]*domain.Bookmark, int, error) {
+	listed, failed, rejected, err := collect(r, "bookmarks", bookmarkFields, func(source Source) ([]*domain.Bookmark, int, error) {
+		return source.Bookmarks(ctx)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result, disabled, _ := reconcile(listed, existing, failed, bookmarkFields, nil)
+
+	if disabled > 0 {
+		r.logger.Info("marking removed bookmarks as disabled",
+			logger.Int("count", disabled))
+	}
+
+	return result, rejected, nil
+}
+
+// entryFields gives access to the fields services and bookmarks share
+type entryFields struct {
+	id         string
+	sources    *[]string
+	disabled   *bool
+	updatedAt  *time.Time
+	counter    *int64
+	frecency   *float64
+	createdAt  *time.Time
+	lastUsedAt *time.Time
+}
+
+// serviceFields gives access to the shared fields of a service
+func serviceFields(svc *domain.Service) entryFields {
+	return entryFields{
+		id:         svc.ID,
+		sources:    &svc.Sources,
+		disabled:   &svc.Disabled,
+		updatedAt:  &svc.UpdatedAt,
+		counter:    &svc.Counter,
+		frecency:   &svc.Frecency,
+		createdAt:  &svc.CreatedAt,
+		lastUsedAt: &svc.LastUsedAt,
+	}
+}
+
+// bookmarkFields gives access to the shared fields of a bookmark
+func bookmarkFields(bm *domain.Bookmark) entryFields {
+	return entryFields{
+		id:         bm.ID,
+		sources:    &bm.Sources,
+		disabled:   &bm.Disabled,
+		updatedAt:  &bm.UpdatedAt,
+		counter:    &bm.Counter,
+		frecency:   &bm.Frecency,
+		createdAt:  &bm.CreatedAt,
+		lastUsedAt: &bm.LastUsedAt,
+	}
+}
+
+// collect loads the entries of every source and merges them by ID.
+// It returns the listed entries, the sources that failed to load and how many
+// entries the sources rejected. It fails only when no source could be loaded.
+func collect[T any](
+	r *Reconciler,
+	kind string,
+	fields func(*T) entryFields,
+	load func(Source) ([]*T, int, error),
+) ([]*T, map[string]bool, int, error) {
+	var (
+		listed   []*T
+		byID     = make(map[string]*T)
+		failed   = make(map[string]bool)
+		loaded   int
+		rejected int
+		errs     []error
+	)
+
+	for _, source := range r.sources {
+		entries, count, err := load(source)
+		if errors.Is(err, ErrNoBookmarks) {
+			continue
+		}
+		if err != nil {
+			failed[source.Name()] = true
+			errs = append(errs, fmt.Errorf("%s: %w", source.Name(), err))
+			continue
+		}
+		loaded++
+		rejected += count
+
+		r.logger.Info("loaded "+kind+" from source",
+			logger.String("source", source.Name()),
+			logger.Int("count", len(entries)),
+			logger.Int("rejected", count))
+
+		for _, entry := range entries {
+			f := fields(entry)
+			if first, ok := byID[f.id]; ok {
+				firstSources := fields(first).sources
+				*firstSources = appendSource(*firstSources, source.Name())
+				continue
+			}
+			*f.sources = []string{source.Name()}
+			byID[f.id] = entry
+			listed = append(listed, entry)
+		}
+	}
+
+	if loaded == 0 {
+		if len(errs) == 0 {
+			// Only bookmarks can be missing from every source
+			return nil, nil, 0, ErrNoBookmarks
+		}
+		return nil, nil, 0, fmt.Errorf("failed to reload %s: %w", kind, errors.Join(errs...))
+	}
+	for _, err := range errs {
+		r.logger.Warn("failed to load "+kind+", keeping the ones of the source",
+			logger.Error(err))
+	}
+
+	return listed, failed, rejected, nil
+}
+
+// reconcile merges the listed entries with the existing ones and disables the entries
+// every owner dropped. Entries reported by managed (nil for none) are kept as they are.
+// It returns the full list to index, how many entries were disabled and how many managed ones were kept.
+func reconcile[T any](
+	listed, existing []*T,
+	failed map[string]bool,
+	fields func(*T) entryFields,
+	managed func(*T) bool,
+) ([]*T, int, int) {
+	isManaged := func(entry *T) bool { return managed != nil && managed(entry) }
+
+	current := make(map[string]*T, len(existing))
+	for _, entry := range existing {
+		current[fields(entry).id] = entry
+	}
+
+	result := make([]*T, 0, len(listed)+len(existing))
+	listedIDs := make(map[string]bool, len(listed))
+	kept := 0
+
+	for _, entry := range listed {
+		f := fields(entry)
+		listedIDs[f.id] = true
+
+		prev, ok := current[f.id]
+		switch {
+		case !ok:
+			result = append(result, entry)
+		case isManaged(prev):
+			result = append(result, withSources(prev, reconcileSources(*f.sources, *fields(prev).sources, failed), fields))
+			kept++
+		default:
+			// Keep the usage learned so far, sources start every entry from zero
+			*f.sources = reconcileSources(*f.sources, *fields(prev).sources, failed)
+			carryUsage(f, fields(prev))
+			result = append(result, entry)
+		}
+	}
+
+	now := time.Now()
+	disabled := 0
+
+	for _, prev := range existing {
+		f := fields(prev)
+		if listedIDs[f.id] {
+			continue
+		}
+
+		remaining := reconcileSources(nil, *f.sources, failed)
+		switch {
+		case len(remaining) > 0 || len(*f.sources) == 0:
+			// Still owned by a source that failed to load, or by the API
+			if isManaged(prev) {
+				kept++
+			}
+			result = append(result, withSources(prev, remaining, fields))
+		case *f.disabled:
+			// Already disabled: keep the time it was dropped for the garbage collector
+			result = append(result, prev)
+		default:
+			// Every source that owned the entry dropped it.
+			// Searches may be reading the indexed entry: disable a copy
+			dropped := *prev
+			df := fields(&dropped)
+			*df.disabled = true
+			*df.updatedAt = now
+			result = append(result, &dropped)
+			disabled++
+		}
+	}
+
+	return result, disabled, kept
+}
+
+// reconcileSources returns the sources of an entry after a reload: the existing ones
+// that still list it, failed to load this time or are the API, then the new ones.
+// A source that is no longer enabled has dropped its entries.
+func reconcileSources(listing, existing []string, failed map[string]bool) []string {
+	result := make([]string, 0, len(existing)+len(listing))
+	for _, source := range existing {
+		if failed[source] || source == domain.SourceAPI || slices.Contains(listing, source) {
+			result = appendSource(result, source)
+		}
+	}
+	for _, source := range listing {
+		result = appendSource(result, source)
+	}
+	return result
+}
+
+// appendSource adds a source to a list unless it is already there
+func appendSource(sources []string, source string) []string {
+	if slices.Contains(sources, source) {
+		return sources
+	}
+	return append(sources, source)
+}
+
+// withSources returns the entry with the given sources.
+// The entry is copied when its sources change, since searches may be reading it.
+func withSources[T any](entry *T, sources []string, fields func(*T) entryFields) *T {
+	if slices.Equal(*fields(entry).sources, sources) {
+		return entry
+	}
+	updated := *entry
+	*fields(&updated).sources = sources
+	return &updated
+}
+
+// carryUsage copies the usage history of an existing entry onto its reloaded version
+func carryUsage(entry, existing entryFields) {
+	*entry.counter = *existing.counter
+	*entry.frecency = *existing.frecency
+	*entry.lastUsedAt = *existing.lastUsedAt
+	if !existing.createdAt.IsZero() {
+		*entry.createdAt = *existing.createdAt
+	}
+}
@@ -0,0 +1,241 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+// fakeSource lists fixed entries, returning fresh copies like real sources do
+type fakeSource struct {
+	name      string
+	services  []domain.Service
+	bookmarks []domain.Bookmark
+	rejected  int
+	err       error
+}
+
+func (f *fakeSource) Name() string { return f.name }
+
+func (f *fakeSource) Services(_ context.Context) ([]*domain.Service, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	services := make([]*domain.Service, 0, len(f.services))
+	for _, svc := range f.services {
+		svc.Sources = []string{f.name}
+		services = append(services, &svc)
+	}
+	return services, nil
+}
+
+func (f *fakeSource) Bookmarks(_ context.Context) ([]*domain.Bookmark, int, error) {
+	if f.bookmarks == nil {
+		return nil, 0, ErrNoBookmarks
+	}
+	if f.err != nil {
+		return nil, 0, f.err
+	}
+	bookmarks := make([]*domain.Bookmark, 0, len(f.bookmarks))
+	for _, bm := range f.bookmarks {
+		bm.Sources = []string{f.name}
+		bookmarks = append(bookmarks, &bm)
+	}
+	return bookmarks, f.rejected, nil
+}
+
+func (f *fakeSource) HasBookmarks() bool { return f.bookmarks != nil }
+
+func newTestReconciler(sources ...Source) *Reconciler {
+	return NewReconciler(sources, "", logger.New("error", false))
+}
+
+func servicesByID(services []*domain.Service) map[string]*domain.Service {
+	byID := make(map[string]*domain.Service, len(services))
+	for _, svc := range services {
+		byID[svc.ID] = svc
+	}
+	return byID
+}
+
+func TestReconciler_MergesSourcesByID(t *testing.T) {
+	primary := &fakeSource{name: "primary", services: []domain.Service{
+		{ID: "grafana.domain.ext", DisplayName: "Grafana"},
+		{ID: "sonarr.domain.ext", DisplayName: "Sonarr"},
+	}}
+	secondary := &fakeSource{name: "secondary", services: []domain.Service{
+		{ID: "grafana.domain.ext", DisplayName: "Dashboards"},
+		{ID: "nas.lan", DisplayName: "NAS"},
+	}}
+
+	services, err := newTestReconciler(primary, secondary).Services(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Services() error = %v", err)
+	}
+	if len(services) != 3 {
+		t.Fatalf("Services() returned %d services, want 3", len(services))
+	}
+
+	grafana := servicesByID(services)["grafana.domain.ext"]
+	if grafana.DisplayName != "Grafana" {
+		t.Errorf("DisplayName = %q, want the first source's definition", grafana.DisplayName)
+	}
+	if !slices.Equal(grafana.Sources, []string{"primary", "secondary"}) {
+		t.Errorf("Sources = %v, want [primary secondary]", grafana.Sources)
+	}
+}
+
+func TestReconciler_DisablesOnlyWhenEveryOwnerDropped(t *testing.T) {
+	droppedAt := time.Now().Add(-48 * time.Hour)
+	existing := []*domain.Service{
+		{ID: "shared.domain.ext", Counter: 7, Sources: []string{"primary", "secondary"}},
+		{ID: "gone.domain.ext", Sources: []string{"primary"}},
+		{ID: "old.domain.ext", Sources: []string{"primary"}, Disabled: true, UpdatedAt: droppedAt},
+	}
+	primary := &fakeSource{name: "primary", services: []domain.Service{{ID: "new.domain.ext"}}}
+	secondary := &fakeSource{name: "secondary", services: []domain.Service{{ID: "shared.domain.ext"}}}
+
+	services, err := newTestReconciler(primary, secondary).Services(context.Background(), existing)
+	if err != nil {
+		t.Fatalf("Services() error = %v", err)
+	}
+	byID := servicesByID(services)
+
+	shared := byID["shared.domain.ext"]
+	if shared.Disabled {
+		t.Error("service still listed by one of its sources was disabled")
+	}
+	if !slices.Equal(shared.Sources, []string{"secondary"}) {
+		t.Errorf("Sources = %v, want [secondary]", shared.Sources)
+	}
+	if shared.Counter != 7 {
+		t.Errorf("Counter = %d, want the usage carried over", shared.Counter)
+	}
+
+	if gone := byID["gone.domain.ext"]; !gone.Disabled {
+		t.Error("service dropped by its only source was not disabled")
+	}
+	if existing[1].Disabled {
+		t.Error("the indexed service was modified instead of a copy")
+	}
+
+	if old := byID["old.domain.ext"]; !old.UpdatedAt.Equal(droppedAt) {
+		t.Errorf("already disabled service UpdatedAt = %v, want %v", old.UpdatedAt, droppedAt)
+	}
+}
+
+func TestReconciler_KeepsEntriesOfFailedSources(t *testing.T) {
+	existing := []*domain.Service{
+		{ID: "shared.domain.ext", Sources: []string{"primary", "secondary"}},
+		{ID: "secondary.domain.ext", Sources: []string{"secondary"}},
+	}
+	primary := &fakeSource{name: "primary", services: []domain.Service{{ID: "other.domain.ext"}}}
+	secondary := &fakeSource{name: "secondary", err: errors.New("unreachable")}
+
+	services, err := newTestReconciler(primary, secondary).Services(context.Background(), existing)
+	if err != nil {
+		t.Fatalf("Services() error = %v", err)
+	}
+	byID := servicesByID(services)
+
+	for _, id := range []string{"shared.domain.ext", "secondary.domain.ext"} {
+		if svc := byID[id]; svc == nil || svc.Disabled {
+			t.Errorf("%s = %+v, want it kept while its source is unavailable", id, svc)
+		}
+	}
+	if sources := byID["shared.domain.ext"].Sources; !slices.Equal(sources, []string{"secondary"}) {
+		t.Errorf("Sources = %v, want [secondary]", sources)
+	}
+
+	// Nothing is changed when no source answers
+	if _, err := newTestReconciler(secondary).Services(context.Background(), existing); err == nil {
+		t.Error("Services() error = nil, want an error when every source fails")
+	}
+}
+
+func TestReconciler_DisablesEntriesOfRemovedSources(t *testing.T) {
+	existing := []*domain.Service{
+		{ID: "shared.domain.ext", Sources: []string{"primary", "removed"}},
+		{ID: "removed.domain.ext", Sources: []string{"removed"}},
+	}
+	primary := &fakeSource{name: "primary", services: []domain.Service{{ID: "shared.domain.ext"}}}
+
+	services, err := newTestReconciler(primary).Services(context.Background(), existing)
+	if err != nil {
+		t.Fatalf("Services() error = %v", err)
+	}
+	byID := servicesByID(services)
+
+	if sources := byID["shared.domain.ext"].Sources; !slices.Equal(sources, []string{"primary"}) {
+		t.Errorf("Sources = %v, want [primary]", sources)
+	}
+	if removed := byID["removed.domain.ext"]; !removed.Disabled {
+		t.Error("service of a source that is no longer enabled was not disabled")
+	}
+}
+
+func TestReconciler_KeepsAPIServices(t *testing.T) {
+	existing := []*domain.Service{
+		{ID: "edited.domain.ext", Name: "custom", Sources: []string{domain.SourceHomepage, domain.SourceAPI}},
+		{ID: "adhoc.lan", Sources: []string{domain.SourceAPI}},
+	}
+	homepage := &fakeSource{name: domain.SourceHomepage, services: []domain.Service{{ID: "sonarr.domain.ext"}}}
+
+	services, err := newTestReconciler(homepage).Services(context.Background(), existing)
+	if err != nil {
+		t.Fatalf("Services() error = %v", err)
+	}
+	byID := servicesByID(services)
+
+	edited := byID["edited.domain.ext"]
+	if edited.Disabled || edited.Name != "custom" {
+		t.Errorf("API-managed service = %+v, want it kept as is", edited)
+	}
+	if !slices.Equal(edited.Sources, []string{domain.SourceAPI}) {
+		t.Errorf("Sources = %v, want [api]", edited.Sources)
+	}
+	if adhoc := byID["adhoc.lan"]; adhoc != existing[1] {
+		t.Errorf("API service = %+v, want it untouched", adhoc)
+	}
+}
+
+func TestReconciler_Bookmarks(t *testing.T) {
+	existing := []*domain.Bookmark{
+		{ID: "gh", Counter: 3, Sources: []string{"primary"}},
+		{ID: "old", Sources: []string{"primary"}},
+	}
+	primary := &fakeSource{name: "primary", rejected: 2, bookmarks: []domain.Bookmark{{ID: "gh"}}}
+	servicesOnly := &fakeSource{name: "secondary"}
+
+	bookmarks, rejected, err := newTestReconciler(servicesOnly, primary).Bookmarks(context.Background(), existing)
+	if err != nil {
+		t.Fatalf("Bookmarks() error = %v", err)
+	}
+	if rejected != 2 {
+		t.Errorf("rejected = %d, want 2", rejected)
+	}
+	if len(bookmarks) != 2 {
+		t.Fatalf("Bookmarks() returned %d bookmarks, want 2", len(bookmarks))
+	}
+	for _, bm := range bookmarks {
+		switch bm.ID {
+		case "gh":
+			if bm.Disabled || bm.Counter != 3 {
+				t.Errorf("listed bookmark = %+v, want it enabled with its usage", bm)
+			}
+		case "old":
+			if !bm.Disabled {
+				t.Error("bookmark dropped by its source was not disabled")
+			}
+		}
+	}
+
+	if _, _, err := newTestReconciler(servicesOnly).Bookmarks(context.Background(), nil); !errors.Is(err, ErrNoBookmarks) {
+		t.Errorf("Bookmarks() error = %v, want ErrNoBookmarks", err)
+	}
+}
@@ -0,0 +1,70 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MrSnakeDoc/jump/internal/config"
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+// Factory builds a source from the configuration
+type Factory func(cfg *config.Config, log logger.Logger) (Source, error)
+
+var (
+	// ErrUnknownSource is returned when the configuration enables a source that is not registered
+	ErrUnknownSource = errors.New("unknown source")
+
+	factories = map[string]Factory{}
+)
+
+// Register makes a source available to JUMP_SOURCES under its name.
+// Sources register themselves from an init function.
+func Register(name string, factory Factory) {
+	if name == domain.SourceAPI {
+		panic(fmt.Sprintf("source name %q is reserved", name))
+	}
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("source %q registered twice", name))
+	}
+	factories[name] = factory
+}
+
+// Available returns the names of the registered sources, sorted
+func Available() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Enabled builds the sources named in the configuration, in the configured order
+func Enabled(cfg *config.Config, log logger.Logger) ([]Source, error) {
+	enabled := make([]Source, 0, len(cfg.Sources))
+	seen := make(map[string]bool, len(cfg.Sources))
+
+	for _, name := range cfg.Sources {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		factory, ok := factories[name]
+		if !ok {
+			return nil, fmt.Errorf("%w %q (available: %s)", ErrUnknownSource, name, strings.Join(Available(), ", "))
+		}
+
+		source, err := factory(cfg, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize source %q: %w", name, err)
+		}
+		enabled = append(enabled, source)
+	}
+
+	return enabled, nil
+}
@@ -0,0 +1,28 @@
+package sources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MrSnakeDoc/jump/internal/config"
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/MrSnakeDoc/jump/internal/logger"
+)
+
+func TestEnabled_UnknownSource(t *testing.T) {
+	cfg := &config.Config{Sources: []string{"nope"}}
+
+	if _, err := Enabled(cfg, logger.New("error", false)); !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("Enabled() error = %v, want ErrUnknownSource", err)
+	}
+}
+
+func TestRegister_ReservedName(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Register() should have panicked for the api source name")
+		}
+	}()
+
+	Register(domain.SourceAPI, nil)
+}
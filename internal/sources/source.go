@@ -0,0 +1,28 @@
+package sources
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+)
+
+// ErrNoBookmarks is returned by sources that are not configured to list bookmarks
+var ErrNoBookmarks = errors.New("source lists no bookmarks")
+
+// Source is a place services and bookmarks are discovered from (Homepage, ...).
+// Entries are returned fresh on every call: the caller owns them.
+type Source interface {
+	// Name is the provenance recorded in the Sources of the entries (e.g. "homepage")
+	Name() string
+
+	// Services returns the services currently listed by the source
+	Services(ctx context.Context) ([]*domain.Service, error)
+
+	// Bookmarks returns the bookmarks currently listed by the source and how many
+	// were rejected by the bookmark policy, or ErrNoBookmarks
+	Bookmarks(ctx context.Context) ([]*domain.Bookmark, int, error)
+
+	// HasBookmarks reports whether the source is configured to list bookmarks
+	HasBookmarks() bool
+}
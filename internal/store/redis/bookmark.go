@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/MrSnakeDoc/jump/internal/domain"
 	"github.com/redis/go-redis/v9"
@@ -94,6 +95,18 @@ func (s *Store) DeleteBookmark(ctx context.Context, id string) error {
 	return nil
 }
 
+// UpdateBookmarkCounter increments the usage counter and frecency for a bookmark
+func (s *Store) UpdateBookmarkCounter(ctx context.Context, id string) error {
+	bookmark, err := s.GetBookmark(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	bookmark.RecordUse(time.Now())
+
+	return s.SaveBookmark(ctx, bookmark)
+}
+
 // SaveBookmarksMany stores multiple bookmarks in Redis (bulk operation)
 func (s *Store) SaveBookmarksMany(ctx context.Context, bookmarks []*domain.Bookmark) error {
 	pipe := s.client.Pipeline()
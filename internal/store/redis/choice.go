@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// DefaultChoiceTTL is the default TTL for picks recorded from the chooser (30 days)
+	DefaultChoiceTTL = 30 * 24 * time.Hour
+
+	// ChoiceRealmService is the realm of picks among services
+	ChoiceRealmService = "service"
+	// ChoiceRealmBookmark is the realm of picks among bookmarks
+	ChoiceRealmBookmark = "bookmark"
+)
+
+// SaveChoice records the ID picked by the user for an ambiguous query
+func (s *Store) SaveChoice(ctx context.Context, realm, query, id string, ttl time.Duration) error {
+	if err := s.client.Set(ctx, ChoiceKey(realm, query), id, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save choice: %w", err)
+	}
+	return nil
+}
+
+// GetChoice retrieves the ID picked for a query, empty if none was recorded
+func (s *Store) GetChoice(ctx context.Context, realm, query string) (string, error) {
+	id, err := s.client.Get(ctx, ChoiceKey(realm, query)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil // No pick recorded
+		}
+		return "", fmt.Errorf("failed to get choice: %w", err)
+	}
+	return id, nil
+}
+
+// DeleteChoice forgets the ID picked for a query
+func (s *Store) DeleteChoice(ctx context.Context, realm, query string) error {
+	if err := s.client.Del(ctx, ChoiceKey(realm, query)).Err(); err != nil {
+		return fmt.Errorf("failed to delete choice: %w", err)
+	}
+	return nil
+}
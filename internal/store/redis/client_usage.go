@@ -0,0 +1,84 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+)
+
+// DefaultClientUsageTTL is the default TTL for per-client usage counters (90 days since last use)
+const DefaultClientUsageTTL = 90 * 24 * time.Hour
+
+// IncrementClientUsage increments the usage frecency of a service for a client identity
+func (s *Store) IncrementClientUsage(ctx context.Context, client, serviceID string) error {
+	key := ClientUsageKey(client)
+
+	pipe := s.client.Pipeline()
+	pipe.HIncrByFloat(ctx, key, serviceID, 1)
+	pipe.Expire(ctx, key, DefaultClientUsageTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to increment client usage: %w", err)
+	}
+
+	return nil
+}
+
+// GetClientUsage retrieves the usage frecency of a client identity (service ID -> frecency)
+func (s *Store) GetClientUsage(ctx context.Context, client string) (map[string]float64, error) {
+	values, err := s.client.HGetAll(ctx, ClientUsageKey(client)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client usage: %w", err)
+	}
+
+	return parseClientUsage(values), nil
+}
+
+// DecayClientUsage applies a decay factor to the usage of every client identity.
+// Negligible entries are dropped. Returns the number of clients processed.
+func (s *Store) DecayClientUsage(ctx context.Context, factor float64) (int, error) {
+	clients := 0
+
+	iter := s.client.Scan(ctx, 0, KeyPrefixClientUsage+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		values, err := s.client.HGetAll(ctx, key).Result()
+		if err != nil {
+			// Skip entries that expired or couldn't be retrieved
+			continue
+		}
+
+		pipe := s.client.Pipeline()
+		for id, value := range parseClientUsage(values) {
+			if decayed := domain.DecayValue(value, factor); decayed > 0 {
+				pipe.HSet(ctx, key, id, strconv.FormatFloat(decayed, 'f', -1, 64))
+			} else {
+				pipe.HDel(ctx, key, id)
+			}
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return clients, fmt.Errorf("failed to decay client usage: %w", err)
+		}
+		clients++
+	}
+	if err := iter.Err(); err != nil {
+		return clients, fmt.Errorf("failed to scan client usage: %w", err)
+	}
+
+	return clients, nil
+}
+
+// parseClientUsage converts raw hash values into frecency values, skipping invalid ones
+func parseClientUsage(values map[string]string) map[string]float64 {
+	usage := make(map[string]float64, len(values))
+	for id, value := range values {
+		frecency, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		usage[id] = frecency
+	}
+	return usage
+}
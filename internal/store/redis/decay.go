@@ -0,0 +1,35 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SaveLastDecay records the time of the last frecency decay
+func (s *Store) SaveLastDecay(ctx context.Context, at time.Time) error {
+	if err := s.client.Set(ctx, LastDecayKey(), at.UTC().Format(time.RFC3339Nano), 0).Err(); err != nil {
+		return fmt.Errorf("failed to save last decay: %w", err)
+	}
+	return nil
+}
+
+// GetLastDecay retrieves the time of the last frecency decay, zero if usage was never decayed
+func (s *Store) GetLastDecay(ctx context.Context) (time.Time, error) {
+	value, err := s.client.Get(ctx, LastDecayKey()).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get last decay: %w", err)
+	}
+
+	at, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse last decay: %w", err)
+	}
+	return at, nil
+}
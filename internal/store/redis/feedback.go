@@ -0,0 +1,82 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultLastRedirectTTL is how long the last redirect of a client can be reported wrong
+const DefaultLastRedirectTTL = time.Hour
+
+// LastRedirect is the last service redirect made for a client
+type LastRedirect struct {
+	Query     string    `json:"query"`      // query as typed by the user
+	Key       string    `json:"key"`        // resolution key (cache and chooser pick)
+	ServiceID string    `json:"service_id"` // service the query was sent to
+	At        time.Time `json:"at"`
+}
+
+// SaveLastRedirect records the last service redirect of a client identity
+func (s *Store) SaveLastRedirect(ctx context.Context, client string, last *LastRedirect) error {
+	data, err := json.Marshal(last)
+	if err != nil {
+		return fmt.Errorf("failed to marshal last redirect: %w", err)
+	}
+
+	if err := s.client.Set(ctx, LastRedirectKey(client), data, DefaultLastRedirectTTL).Err(); err != nil {
+		return fmt.Errorf("failed to save last redirect: %w", err)
+	}
+	return nil
+}
+
+// GetLastRedirect retrieves the last service redirect of a client identity, nil if none
+func (s *Store) GetLastRedirect(ctx context.Context, client string) (*LastRedirect, error) {
+	data, err := s.client.Get(ctx, LastRedirectKey(client)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil // Nothing to report
+		}
+		return nil, fmt.Errorf("failed to get last redirect: %w", err)
+	}
+
+	var last LastRedirect
+	if err := json.Unmarshal(data, &last); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal last redirect: %w", err)
+	}
+	return &last, nil
+}
+
+// DeleteLastRedirect forgets the last service redirect of a client identity
+func (s *Store) DeleteLastRedirect(ctx context.Context, client string) error {
+	if err := s.client.Del(ctx, LastRedirectKey(client)).Err(); err != nil {
+		return fmt.Errorf("failed to delete last redirect: %w", err)
+	}
+	return nil
+}
+
+// Demote records that a normalized query should not have resolved to a service:
+// the pair gets a negative weight and its learned count is dropped.
+func (s *Store) Demote(ctx context.Context, query, serviceID string) error {
+	if err := s.incrementBounded(ctx, DemotedKey(query), serviceID); err != nil {
+		return fmt.Errorf("failed to demote resolution: %w", err)
+	}
+	if err := s.client.ZRem(ctx, LearnedKey(query), serviceID).Err(); err != nil {
+		return fmt.Errorf("failed to drop learned resolution: %w", err)
+	}
+
+	return nil
+}
+
+// GetDemoted retrieves the services reported wrong for a normalized query (service ID -> count)
+func (s *Store) GetDemoted(ctx context.Context, query string) (map[string]float64, error) {
+	entries, err := s.client.ZRangeWithScores(ctx, DemotedKey(query), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get demoted resolutions: %w", err)
+	}
+	return zsetScores(entries), nil
+}
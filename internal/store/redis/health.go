@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+)
+
+// DefaultHealthTTL is the default TTL for service health entries (1 hour)
+const DefaultHealthTTL = time.Hour
+
+// SaveHealthMany stores the results of a probe round (bulk operation)
+func (s *Store) SaveHealthMany(ctx context.Context, results []*domain.Health) error {
+	pipe := s.client.Pipeline()
+
+	for _, h := range results {
+		data, err := json.Marshal(h)
+		if err != nil {
+			return fmt.Errorf("failed to marshal health %s: %w", h.ServiceID, err)
+		}
+		pipe.Set(ctx, HealthKey(h.ServiceID), data, DefaultHealthTTL)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save health: %w", err)
+	}
+
+	return nil
+}
+
+// GetAllHealth retrieves every stored service health
+func (s *Store) GetAllHealth(ctx context.Context) ([]*domain.Health, error) {
+	var results []*domain.Health
+
+	iter := s.client.Scan(ctx, 0, KeyPrefixHealth+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		data, err := s.client.Get(ctx, iter.Val()).Bytes()
+		if err != nil {
+			// Skip entries that expired or couldn't be retrieved
+			continue
+		}
+
+		var h domain.Health
+		if err := json.Unmarshal(data, &h); err != nil {
+			continue
+		}
+		results = append(results, &h)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan health: %w", err)
+	}
+
+	return results, nil
+}
@@ -1,6 +1,10 @@
 package redis
 
-import "fmt"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
 
 const (
 	// KeyPrefixService is the prefix for service keys
@@ -9,6 +13,20 @@ const (
 	KeyPrefixCache = "jump:cache:"
 	// KeyAllServices is the key for the set of all service IDs
 	KeyAllServices = "jump:services:all"
+	// KeyPrefixHealth is the prefix for service health keys
+	KeyPrefixHealth = "jump:health:"
+	// KeyPrefixChoice is the prefix for chooser picks
+	KeyPrefixChoice = "jump:choice:"
+	// KeyPrefixClientUsage is the prefix for per-client usage counters
+	KeyPrefixClientUsage = "jump:usage:client:"
+	// KeyPrefixLearned is the prefix for learned query -> service counters
+	KeyPrefixLearned = "jump:learned:"
+	// KeyPrefixDemoted is the prefix for query -> service pairs reported wrong
+	KeyPrefixDemoted = "jump:demoted:"
+	// KeyPrefixLastRedirect is the prefix for the last redirect of each client
+	KeyPrefixLastRedirect = "jump:last:"
+	// KeyLastDecay is the key for the time of the last frecency decay
+	KeyLastDecay = "jump:decay:last"
 )
 
 // ServiceKey returns the Redis key for a service by ID
@@ -33,3 +51,45 @@ func ExtractServiceID(key string) (string, error) {
 	}
 	return key[len(KeyPrefixService):], nil
 }
+
+// HealthKey returns the Redis key for the health of a service
+func HealthKey(id string) string {
+	return KeyPrefixHealth + id
+}
+
+// ChoiceKey returns the Redis key for the pick recorded for a query in a realm
+func ChoiceKey(realm, query string) string {
+	return KeyPrefixChoice + realm + ":" + query
+}
+
+// ClientUsageKey returns the Redis key for the usage counters of a client identity
+func ClientUsageKey(client string) string {
+	return KeyPrefixClientUsage + ClientHash(client)
+}
+
+// LearnedKey returns the Redis key for the services a normalized query resolved to
+func LearnedKey(query string) string {
+	return KeyPrefixLearned + query
+}
+
+// DemotedKey returns the Redis key for the services reported wrong for a normalized query
+func DemotedKey(query string) string {
+	return KeyPrefixDemoted + query
+}
+
+// LastRedirectKey returns the Redis key for the last redirect of a client identity
+func LastRedirectKey(client string) string {
+	return KeyPrefixLastRedirect + ClientHash(client)
+}
+
+// LastDecayKey returns the key for the time of the last frecency decay
+func LastDecayKey() string {
+	return KeyLastDecay
+}
+
+// ClientHash returns a short stable hash of a client identity,
+// so IPs and user names never appear in Redis keys
+func ClientHash(client string) string {
+	sum := sha256.Sum256([]byte(client))
+	return hex.EncodeToString(sum[:8])
+}
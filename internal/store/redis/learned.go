@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// DefaultLearnedTopK is the number of services kept per learned query
+	DefaultLearnedTopK = 5
+	// DefaultLearnedTTL is the default TTL for learned queries (90 days since last use)
+	DefaultLearnedTTL = 90 * 24 * time.Hour
+)
+
+// incrementBoundedScript adds to the score of a member in a sorted set holding at most K members.
+// When the set grows past K, the lowest-scored members are evicted, never the one just counted:
+// a new member always gets the chance to accumulate a count.
+// KEYS[1] = set, ARGV[1] = member, ARGV[2] = increment, ARGV[3] = K, ARGV[4] = TTL in milliseconds
+var incrementBoundedScript = redis.NewScript(`
+redis.call('ZINCRBY', KEYS[1], ARGV[2], ARGV[1])
+local excess = redis.call('ZCARD', KEYS[1]) - tonumber(ARGV[3])
+if excess > 0 then
+	for _, member in ipairs(redis.call('ZRANGE', KEYS[1], 0, excess)) do
+		if excess > 0 and member ~= ARGV[1] then
+			redis.call('ZREM', KEYS[1], member)
+			excess = excess - 1
+		end
+	end
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+
+// incrementBounded counts a member in a sorted set keeping its DefaultLearnedTopK best members
+func (s *Store) incrementBounded(ctx context.Context, key, member string) error {
+	return incrementBoundedScript.Run(ctx, s.client, []string{key},
+		member, 1, DefaultLearnedTopK, DefaultLearnedTTL.Milliseconds()).Err()
+}
+
+// RecordLearned counts a resolution of a normalized query to a service.
+// Only the DefaultLearnedTopK most frequent services are kept per query.
+func (s *Store) RecordLearned(ctx context.Context, query, serviceID string) error {
+	if err := s.incrementBounded(ctx, LearnedKey(query), serviceID); err != nil {
+		return fmt.Errorf("failed to record learned query: %w", err)
+	}
+
+	return nil
+}
+
+// GetLearned retrieves the services a normalized query resolved to (service ID -> count)
+func (s *Store) GetLearned(ctx context.Context, query string) (map[string]float64, error) {
+	entries, err := s.client.ZRangeWithScores(ctx, LearnedKey(query), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get learned query: %w", err)
+	}
+
+	return zsetScores(entries), nil
+}
+
+// zsetScores converts sorted set entries into a member -> score map
+func zsetScores(entries []redis.Z) map[string]float64 {
+	scores := make(map[string]float64, len(entries))
+	for _, entry := range entries {
+		id, ok := entry.Member.(string)
+		if !ok {
+			continue
+		}
+		scores[id] = entry.Score
+	}
+	return scores
+}
@@ -40,7 +40,7 @@ func (s *Store) SaveService(ctx context.Context, service *domain.Service) error
 	key := ServiceKey(service.ID)
 
 	// Store service data
-	if err := s.client.Set(ctx, key, data, DefaultServiceTTL).Err(); err != nil {
+	if err := s.client.Set(ctx, key, data, serviceTTL(service)).Err(); err != nil {
 		return fmt.Errorf("failed to save service: %w", err)
 	}
 
@@ -52,6 +52,15 @@ func (s *Store) SaveService(ctx context.Context, service *domain.Service) error
 	return nil
 }
 
+// serviceTTL returns the TTL of a service entry: services managed through the API
+// have no other source to be rebuilt from, so they never expire
+func serviceTTL(service *domain.Service) time.Duration {
+	if service.HasSource(domain.SourceAPI) {
+		return 0
+	}
+	return DefaultServiceTTL
+}
+
 // GetService retrieves a service from Redis by ID
 func (s *Store) GetService(ctx context.Context, id string) (*domain.Service, error) {
 	key := ServiceKey(id)
@@ -114,15 +123,16 @@ func (s *Store) DeleteService(ctx context.Context, id string) error {
 	return nil
 }
 
-// UpdateServiceCounter increments the usage counter for a service
+// UpdateServiceCounter increments the usage counter and frecency for a service
 func (s *Store) UpdateServiceCounter(ctx context.Context, id string) error {
 	service, err := s.GetService(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	service.Counter++
-	service.LastSeenAt = time.Now()
+	now := time.Now()
+	service.RecordUse(now)
+	service.LastSeenAt = now
 
 	return s.SaveService(ctx, service)
 }
@@ -138,7 +148,7 @@ func (s *Store) SaveServicesMany(ctx context.Context, services []*domain.Service
 		}
 
 		key := ServiceKey(service.ID)
-		pipe.Set(ctx, key, data, DefaultServiceTTL)
+		pipe.Set(ctx, key, data, serviceTTL(service))
 		pipe.SAdd(ctx, AllServicesKey(), service.ID)
 	}
 
@@ -0,0 +1,370 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MrSnakeDoc/jump/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// SnapshotVersion is the version of the snapshot format written by Export
+const SnapshotVersion = 1
+
+const (
+	// ImportModeMerge adds the snapshot to the current state: counters are summed,
+	// current cache entries and picks are kept
+	ImportModeMerge = "merge"
+	// ImportModeReplace wipes the learning state before restoring the snapshot
+	ImportModeReplace = "replace"
+)
+
+var (
+	// ErrUnsupportedSnapshot is returned when importing a snapshot of another format version
+	ErrUnsupportedSnapshot = errors.New("unsupported snapshot version")
+	// ErrInvalidImportMode is returned for an import mode other than merge or replace
+	ErrInvalidImportMode = errors.New("invalid import mode")
+	// ErrInvalidSnapshot is returned when a snapshot has missing or duplicate entries
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+)
+
+// Snapshot is everything Jump has learned, as exported to JSON.
+// Keys are stored without their Redis prefix.
+type Snapshot struct {
+	Version     int                `json:"version"`
+	ExportedAt  time.Time          `json:"exported_at"`
+	Services    []*domain.Service  `json:"services"`
+	Bookmarks   []*domain.Bookmark `json:"bookmarks"`
+	Cache       []SnapshotValue    `json:"cache"`        // query -> cached resolution
+	Choices     []SnapshotValue    `json:"choices"`      // realm:query -> picked ID
+	Learned     []SnapshotScores   `json:"learned"`      // query -> service counts
+	Demoted     []SnapshotScores   `json:"demoted"`      // query -> wrong service counts
+	ClientUsage []SnapshotScores   `json:"client_usage"` // client hash -> service frecency
+}
+
+// SnapshotValue is a string entry of a snapshot
+type SnapshotValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// SnapshotScores is a member -> score entry of a snapshot
+type SnapshotScores struct {
+	Key    string             `json:"key"`
+	Scores map[string]float64 `json:"scores"`
+}
+
+// ImportSummary counts what an import restored
+type ImportSummary struct {
+	Mode      string `json:"mode"`
+	Services  int    `json:"services"`
+	Bookmarks int    `json:"bookmarks"`
+	Entries   int    `json:"entries"` // cache entries, picks, learned, demoted and client usage keys
+}
+
+// Export dumps the learning state: services, bookmarks, cached resolutions,
+// chooser picks, learned and demoted queries and per-client usage.
+// Health results and last redirects are short-lived and left out.
+func (s *Store) Export(ctx context.Context) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Version:    SnapshotVersion,
+		ExportedAt: time.Now().UTC(),
+	}
+
+	var err error
+	if snapshot.Services, err = s.GetAllServices(ctx); err != nil {
+		return nil, err
+	}
+	if snapshot.Bookmarks, err = s.GetAllBookmarks(ctx); err != nil {
+		return nil, err
+	}
+	if snapshot.Cache, err = s.exportValues(ctx, KeyPrefixCache); err != nil {
+		return nil, err
+	}
+	if snapshot.Choices, err = s.exportValues(ctx, KeyPrefixChoice); err != nil {
+		return nil, err
+	}
+	if snapshot.Learned, err = s.exportZSets(ctx, KeyPrefixLearned); err != nil {
+		return nil, err
+	}
+	if snapshot.Demoted, err = s.exportZSets(ctx, KeyPrefixDemoted); err != nil {
+		return nil, err
+	}
+
+	snapshot.ClientUsage = []SnapshotScores{}
+	err = s.scanKeys(ctx, KeyPrefixClientUsage, func(key string) error {
+		values, err := s.client.HGetAll(ctx, key).Result()
+		if err != nil {
+			return fmt.Errorf("failed to get client usage: %w", err)
+		}
+		if len(values) > 0 {
+			snapshot.ClientUsage = append(snapshot.ClientUsage, SnapshotScores{
+				Key:    strings.TrimPrefix(key, KeyPrefixClientUsage),
+				Scores: parseClientUsage(values),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// Import restores a snapshot. In merge mode, service and bookmark counters and
+// frecency are summed with the current ones (the current definitions are kept),
+// learned, demoted and client usage scores are summed, and current cache entries
+// and picks win. In replace mode, the learning state is wiped first.
+// The snapshot is checked before anything is changed, then written in a single
+// transaction: a failed import leaves the current state untouched.
+// Expiry restarts from the default TTLs.
+func (s *Store) Import(ctx context.Context, snapshot *Snapshot, mode string) (*ImportSummary, error) {
+	if mode != ImportModeMerge && mode != ImportModeReplace {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidImportMode, mode)
+	}
+	if err := validateSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+
+	services, bookmarks := snapshot.Services, snapshot.Bookmarks
+	var stale []string
+	if mode == ImportModeReplace {
+		var err error
+		if stale, err = s.learningStateKeys(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		services, bookmarks = s.mergeServices(ctx, services), s.mergeBookmarks(ctx, bookmarks)
+	}
+
+	// Load the trimming script so the transaction can refer to it by hash
+	if err := incrementBoundedScript.Load(ctx, s.client).Err(); err != nil {
+		return nil, fmt.Errorf("failed to load import script: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	if len(stale) > 0 {
+		pipe.Del(ctx, stale...)
+	}
+	for _, service := range services {
+		data, err := json.Marshal(service)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal service %s: %w", service.ID, err)
+		}
+		pipe.Set(ctx, ServiceKey(service.ID), data, serviceTTL(service))
+		pipe.SAdd(ctx, AllServicesKey(), service.ID)
+	}
+	for _, bookmark := range bookmarks {
+		data, err := json.Marshal(bookmark)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal bookmark %s: %w", bookmark.ID, err)
+		}
+		pipe.Set(ctx, BookmarkKey(bookmark.ID), data, DefaultServiceTTL)
+		pipe.SAdd(ctx, AllBookmarksKey(), bookmark.ID)
+	}
+	for _, entry := range snapshot.Cache {
+		pipe.SetNX(ctx, KeyPrefixCache+entry.Key, entry.Value, DefaultCacheTTL)
+	}
+	for _, entry := range snapshot.Choices {
+		pipe.SetNX(ctx, KeyPrefixChoice+entry.Key, entry.Value, DefaultChoiceTTL)
+	}
+	for _, entry := range snapshot.Learned {
+		importZSet(ctx, pipe, KeyPrefixLearned+entry.Key, entry.Scores)
+	}
+	for _, entry := range snapshot.Demoted {
+		importZSet(ctx, pipe, KeyPrefixDemoted+entry.Key, entry.Scores)
+	}
+	for _, entry := range snapshot.ClientUsage {
+		key := KeyPrefixClientUsage + entry.Key
+		for id, score := range entry.Scores {
+			pipe.HIncrByFloat(ctx, key, id, score)
+		}
+		pipe.Expire(ctx, key, DefaultClientUsageTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to import learning state: %w", err)
+	}
+
+	return &ImportSummary{
+		Mode:      mode,
+		Services:  len(services),
+		Bookmarks: len(bookmarks),
+		Entries: len(snapshot.Cache) + len(snapshot.Choices) + len(snapshot.Learned) +
+			len(snapshot.Demoted) + len(snapshot.ClientUsage),
+	}, nil
+}
+
+// validateSnapshot checks the version of a snapshot and that every entry can be restored
+func validateSnapshot(snapshot *Snapshot) error {
+	if snapshot.Version != SnapshotVersion {
+		return fmt.Errorf("%w: %d (expected %d)", ErrUnsupportedSnapshot, snapshot.Version, SnapshotVersion)
+	}
+
+	serviceIDs := make(map[string]bool, len(snapshot.Services))
+	for i, service := range snapshot.Services {
+		if service == nil || service.ID == "" {
+			return fmt.Errorf("%w: service %d has no id", ErrInvalidSnapshot, i)
+		}
+		if serviceIDs[service.ID] {
+			return fmt.Errorf("%w: duplicate service %q", ErrInvalidSnapshot, service.ID)
+		}
+		serviceIDs[service.ID] = true
+	}
+
+	bookmarkIDs := make(map[string]bool, len(snapshot.Bookmarks))
+	for i, bookmark := range snapshot.Bookmarks {
+		if bookmark == nil || bookmark.ID == "" {
+			return fmt.Errorf("%w: bookmark %d has no id", ErrInvalidSnapshot, i)
+		}
+		if bookmarkIDs[bookmark.ID] {
+			return fmt.Errorf("%w: duplicate bookmark %q", ErrInvalidSnapshot, bookmark.ID)
+		}
+		bookmarkIDs[bookmark.ID] = true
+	}
+
+	for name, values := range map[string][]SnapshotValue{"cache": snapshot.Cache, "choices": snapshot.Choices} {
+		for i, entry := range values {
+			if entry.Key == "" {
+				return fmt.Errorf("%w: %s entry %d has no key", ErrInvalidSnapshot, name, i)
+			}
+		}
+	}
+	for name, sets := range map[string][]SnapshotScores{
+		"learned": snapshot.Learned, "demoted": snapshot.Demoted, "client_usage": snapshot.ClientUsage,
+	} {
+		for i, entry := range sets {
+			if entry.Key == "" {
+				return fmt.Errorf("%w: %s entry %d has no key", ErrInvalidSnapshot, name, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// mergeServices sums the usage of the snapshot services into the current ones, when they exist
+func (s *Store) mergeServices(ctx context.Context, services []*domain.Service) []*domain.Service {
+	merged := make([]*domain.Service, len(services))
+	for i, service := range services {
+		merged[i] = service
+		if existing, err := s.GetService(ctx, service.ID); err == nil {
+			existing.MergeUsage(service)
+			merged[i] = existing
+		}
+	}
+	return merged
+}
+
+// mergeBookmarks sums the usage of the snapshot bookmarks into the current ones, when they exist
+func (s *Store) mergeBookmarks(ctx context.Context, bookmarks []*domain.Bookmark) []*domain.Bookmark {
+	merged := make([]*domain.Bookmark, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		merged[i] = bookmark
+		if existing, err := s.GetBookmark(ctx, bookmark.ID); err == nil {
+			existing.MergeUsage(bookmark)
+			merged[i] = existing
+		}
+	}
+	return merged
+}
+
+// importZSet adds scores to a learned or demoted query, keeping the top services only.
+// Services are added from the lowest score up, so the trim evicts the lowest ones.
+func importZSet(ctx context.Context, pipe redis.Pipeliner, key string, scores map[string]float64) {
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return scores[ids[i]] < scores[ids[j]] })
+
+	for _, id := range ids {
+		incrementBoundedScript.EvalSha(ctx, pipe, []string{key},
+			id, scores[id], DefaultLearnedTopK, DefaultLearnedTTL.Milliseconds())
+	}
+}
+
+// learningStateKeys lists the keys a replace import wipes: everything a snapshot restores
+func (s *Store) learningStateKeys(ctx context.Context) ([]string, error) {
+	keys := []string{AllServicesKey(), AllBookmarksKey()}
+
+	services, err := s.client.SMembers(ctx, AllServicesKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service IDs: %w", err)
+	}
+	for _, id := range services {
+		keys = append(keys, ServiceKey(id))
+	}
+
+	bookmarks, err := s.client.SMembers(ctx, AllBookmarksKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookmark IDs: %w", err)
+	}
+	for _, id := range bookmarks {
+		keys = append(keys, BookmarkKey(id))
+	}
+
+	for _, prefix := range []string{KeyPrefixCache, KeyPrefixChoice, KeyPrefixLearned, KeyPrefixDemoted, KeyPrefixClientUsage} {
+		err := s.scanKeys(ctx, prefix, func(key string) error {
+			keys = append(keys, key)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// exportValues dumps the string keys under a prefix
+func (s *Store) exportValues(ctx context.Context, prefix string) ([]SnapshotValue, error) {
+	values := []SnapshotValue{}
+	err := s.scanKeys(ctx, prefix, func(key string) error {
+		value, err := s.client.Get(ctx, key).Result()
+		if errors.Is(err, redis.Nil) {
+			return nil // Expired since the scan
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", key, err)
+		}
+		values = append(values, SnapshotValue{Key: strings.TrimPrefix(key, prefix), Value: value})
+		return nil
+	})
+	return values, err
+}
+
+// exportZSets dumps the sorted sets under a prefix
+func (s *Store) exportZSets(ctx context.Context, prefix string) ([]SnapshotScores, error) {
+	sets := []SnapshotScores{}
+	err := s.scanKeys(ctx, prefix, func(key string) error {
+		entries, err := s.client.ZRangeWithScores(ctx, key, 0, -1).Result()
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", key, err)
+		}
+		if len(entries) > 0 {
+			sets = append(sets, SnapshotScores{Key: strings.TrimPrefix(key, prefix), Scores: zsetScores(entries)})
+		}
+		return nil
+	})
+	return sets, err
+}
+
+// scanKeys calls fn for every key under a prefix
+func (s *Store) scanKeys(ctx context.Context, prefix string, fn func(key string) error) error {
+	iter := s.client.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		if err := fn(iter.Val()); err != nil {
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan %s keys: %w", strings.TrimSuffix(prefix, ":"), err)
+	}
+	return nil
+}
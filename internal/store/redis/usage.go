@@ -10,6 +10,11 @@ func (s *Store) IncrementUsage(ctx context.Context, serviceID string) error {
 	return s.UpdateServiceCounter(ctx, serviceID)
 }
 
+// IncrementBookmarkUsage increments the usage counter for a bookmark
+func (s *Store) IncrementBookmarkUsage(ctx context.Context, bookmarkID string) error {
+	return s.UpdateBookmarkCounter(ctx, bookmarkID)
+}
+
 // GetUsageStats retrieves usage statistics for all services
 func (s *Store) GetUsageStats(ctx context.Context) (map[string]int64, error) {
 	services, err := s.GetAllServices(ctx)
@@ -195,13 +195,13 @@ func TestUsageLearning(t *testing.T) {
 			ID:       "admin",
 			Name:     "admin",
 			Hostname: "admin.domain.ext",
-			Counter:  100, // Heavily used
+			Frecency: 100, // Heavily used
 		},
 		{
 			ID:       "adguard",
 			Name:     "adguard",
 			Hostname: "adguard.domain.ext",
-			Counter:  5, // Rarely used
+			Frecency: 5, // Rarely used
 		},
 	}
 
@@ -215,8 +215,8 @@ func TestUsageLearning(t *testing.T) {
 
 	t.Logf("Query: ad")
 	for i, c := range candidates {
-		t.Logf("  %d. %s (total: %.2f, lexical: %.2f, usage: %.2f, counter: %d)",
-			i+1, c.Service.Hostname, c.TotalScore, c.LexicalScore, c.UsageScore, c.Service.Counter)
+		t.Logf("  %d. %s (total: %.2f, lexical: %.2f, usage: %.2f, frecency: %.2f)",
+			i+1, c.Service.Hostname, c.TotalScore, c.LexicalScore, c.UsageScore, c.Service.Frecency)
 	}
 
 	// The heavily used service (admin) should rank higher despite both being prefix matches
@@ -229,12 +229,12 @@ func TestUsageLearning(t *testing.T) {
 // TestMixedScenarios tests complex real-world scenarios
 func TestMixedScenarios(t *testing.T) {
 	services := []*domain.Service{
-		{ID: "adguard", Name: "adguard", Hostname: "adguard.domain.ext", Counter: 10},
-		{ID: "adguard-ha", Name: "adguard", Hostname: "adguard.ha.domain.ext", Counter: 5},
-		{ID: "jellyfin", Name: "jellyfin", Hostname: "jellyfin.domain.ext", Counter: 20},
-		{ID: "jellyseerr", Name: "jellyseerr", Hostname: "jellyseerr.domain.ext", Counter: 8},
-		{ID: "traefik", Name: "traefik", Hostname: "traefik.domain.ext", Counter: 15},
-		{ID: "uptime", Name: "uptime", Hostname: "uptime.domain.ext", Counter: 3},
+		{ID: "adguard", Name: "adguard", Hostname: "adguard.domain.ext", Frecency: 10},
+		{ID: "adguard-ha", Name: "adguard", Hostname: "adguard.ha.domain.ext", Frecency: 5},
+		{ID: "jellyfin", Name: "jellyfin", Hostname: "jellyfin.domain.ext", Frecency: 20},
+		{ID: "jellyseerr", Name: "jellyseerr", Hostname: "jellyseerr.domain.ext", Frecency: 8},
+		{ID: "traefik", Name: "traefik", Hostname: "traefik.domain.ext", Frecency: 15},
+		{ID: "uptime", Name: "uptime", Hostname: "uptime.domain.ext", Frecency: 3},
 	}
 
 	scenarios := []struct {
@@ -301,3 +301,52 @@ func TestMixedScenarios(t *testing.T) {
 		})
 	}
 }
+
+// TestFuzzyMatching tests typo, subsequence and acronym matching on a realistic homelab
+func TestFuzzyMatching(t *testing.T) {
+	services := []*domain.Service{
+		{ID: "nextcloud", Name: "nextcloud", Hostname: "nextcloud.domain.ext"},
+		{ID: "navidrome", Name: "navidrome", Hostname: "navidrome.domain.ext"},
+		{ID: "jellyfin", Name: "jellyfin", Hostname: "jellyfin.domain.ext"},
+		{ID: "jellyseerr", Name: "jellyseerr", Hostname: "jellyseerr.domain.ext"},
+		{ID: "jackett", Name: "jackett", Hostname: "jackett.domain.ext"},
+		{ID: "traefik", Name: "traefik", Hostname: "traefik.domain.ext"},
+		{ID: "homeassistant", Name: "homeassistant", Hostname: "home-assistant.domain.ext"},
+		{ID: "adguardha", Name: "adguardha", Hostname: "adguardha.domain.ext"},
+		{ID: "vaultwarden", Name: "vaultwarden", Hostname: "vaultwarden.domain.ext"},
+	}
+
+	tests := []struct {
+		name        string
+		queryString string
+		expectedTop string
+	}{
+		{"subsequence", "nc", "nextcloud.domain.ext"},
+		{"subsequence with gaps", "trfk", "traefik.domain.ext"},
+		{"transposition", "jellyfni", "jellyfin.domain.ext"},
+		{"missing letter", "valtwarden", "vaultwarden.domain.ext"},
+		{"acronym beats substring", "ha", "home-assistant.domain.ext"},
+		{"consonants", "vltwrdn", "vaultwarden.domain.ext"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := domain.RankCandidates(domain.ParseQuery(tt.queryString), services)
+			if len(candidates) == 0 {
+				t.Fatalf("No candidates returned for query: %s", tt.queryString)
+			}
+
+			if top := candidates[0].Service.Hostname; top != tt.expectedTop {
+				t.Errorf("Query %q: expected %s, got %s", tt.queryString, tt.expectedTop, top)
+				for i, c := range candidates {
+					t.Logf("  %d. %s (lexical: %.2f)", i+1, c.Service.Hostname, c.LexicalScore)
+				}
+			}
+		})
+	}
+
+	// Unrelated queries must not match anything
+	if candidates := domain.RankCandidates(domain.ParseQuery("xyz"), services); len(candidates) != 0 {
+		t.Errorf("Expected no match for unrelated query, got %d candidates", len(candidates))
+	}
+}